@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// commands are what the program does when its first argument names
+// one; the launch configurations "Debug Module 01 (main-and-entrypoint):
+// args", ": env" and ": config" run them.
+var commands = map[string]func(args []string) error{
+	"args":   runArgs,
+	"env":    runEnv,
+	"config": runConfig,
+}
+
+// runArgs greets as the command line says: flags first, and then any
+// other words.
+func runArgs(args []string) error {
+	fs := flag.NewFlagSet("args", flag.ExitOnError)
+	flags := addFlags(fs)
+	fs.Parse(args)
+
+	// 🔍 SET BREAKPOINT HERE — Compare args with fs.Args(): parsing stops at the first word that is not a flag
+	fmt.Printf("Arguments after the command: %q\n", args)
+	fmt.Printf("Words after the flags: %q\n", fs.Args())
+	return greet(defaults(), flags())
+}
+
+// runEnv greets as the GREET_* environment variables say.
+func runEnv(args []string) error {
+	if len(args) > 0 {
+		return errors.New("env takes no arguments: set GREET_NAME, GREET_COUNT or GREET_LOUD")
+	}
+	var vars []string
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, envPrefix) {
+			vars = append(vars, kv)
+		}
+	}
+	slices.Sort(vars)
+	fmt.Printf("Environment: %q\n", vars)
+	return greet(defaults(), fromEnv())
+}
+
+// runConfig greets with every source combined. Each one overrides the
+// ones before it: defaults, then the -config file, then the
+// environment, then the flags.
+func runConfig(args []string) error {
+	fs := flag.NewFlagSet("config", flag.ExitOnError)
+	path := fs.String("config", "", "read settings from `file`")
+	flags := addFlags(fs)
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		return fmt.Errorf("config takes only flags, got %q", fs.Args())
+	}
+
+	layers := []layer{defaults()}
+	if *path != "" {
+		file, err := readFile(*path)
+		if err != nil {
+			return err
+		}
+		layers = append(layers, file)
+	}
+	layers = append(layers, fromEnv(), flags())
+	return greet(layers...)
+}
+
+// greet resolves the settings from layers, shows where each came from
+// and prints the greeting.
+func greet(layers ...layer) error {
+	s, merged, err := resolve(layers...)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Settings:")
+	for _, k := range keys {
+		fmt.Printf("  %-5s = %-8s from %s\n", k, merged[k].raw, merged[k].origin)
+	}
+	// 👀 WATCH s — the settings the rest of the program sees
+	msg := "Hello, " + s.Name + "!"
+	if s.Loud {
+		msg = strings.ToUpper(msg)
+	}
+	for range s.Count {
+		fmt.Println(msg)
+	}
+	return nil
+}
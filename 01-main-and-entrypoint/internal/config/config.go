@@ -0,0 +1,34 @@
+// Package config shows that package-level variables are initialized in
+// dependency order, not in the order they are declared, and that a file
+// may have several init functions.
+package config
+
+import "debugger-lab/01-main-and-entrypoint/internal/initlog"
+
+// Greeting is declared first but initialized last: it needs prefix and
+// Name.
+var Greeting = initlog.Value("config: var Greeting", prefix+Name)
+
+var prefix = initlog.Value("config: var prefix", "Hello, ")
+
+// Name is the name to greet.
+var Name = initlog.Value("config: var Name", defaultName())
+
+func defaultName() string {
+	// 🔍 SET BREAKPOINT HERE — Initializing config.Name: check the Call Stack
+	return "gopher" // 👀 prefix is already set, Greeting is still empty
+}
+
+// Loud is set by the second init function.
+var Loud bool
+
+// 🔍 SET BREAKPOINT HERE — init.0 runs after every variable of the package
+func init() {
+	initlog.Record("config: init #1")
+}
+
+// 🔍 SET BREAKPOINT HERE — init.1: same file, runs next
+func init() {
+	initlog.Record("config: init #2")
+	Loud = Greeting != "" // 👀 Greeting is set by now
+}
@@ -0,0 +1,25 @@
+// Package initlog records initialization steps in the order they run, so
+// that main can print them. It imports nothing from the module, so it is
+// ready before any package that uses it starts initializing.
+package initlog
+
+// steps has no initializer: it must not depend on being initialized
+// itself.
+var steps []string
+
+// Record notes that step has run.
+func Record(step string) {
+	steps = append(steps, step)
+}
+
+// Value records step and returns v, to wrap a package-level variable's
+// initializer: var x = initlog.Value("pkg: var x", 42).
+func Value[T any](step string, v T) T {
+	Record(step)
+	return v
+}
+
+// Steps returns the steps recorded so far.
+func Steps() []string {
+	return steps
+}
@@ -0,0 +1,68 @@
+// Package inittrace runs a program with GODEBUG=inittrace=1 and parses
+// what the runtime reports on stderr: one line for each package it
+// initializes, in order, such as
+//
+//	init os @0.67 ms, 0.011 ms clock, 416 bytes, 9 allocs
+//
+// Packages with nothing to initialize, no init function and no
+// package-level variable that needs code, are not reported.
+package inittrace
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+)
+
+// Init is one package initialization.
+type Init struct {
+	Package string  // import path; "main" for the main package
+	At      float64 // ms since the program started
+	Clock   float64 // ms spent in the package's initialization
+	Bytes   int     // heap bytes allocated
+	Allocs  int     // heap allocations
+}
+
+var lineRe = regexp.MustCompile(`^init (\S+) @([\d.]+) ms, ([\d.]+) ms clock, (\d+) bytes, (\d+) allocs$`)
+
+// Parse reads inittrace lines from r, ignoring any other output.
+func Parse(r io.Reader) ([]Init, error) {
+	var inits []Init
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		m := lineRe.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		in := Init{Package: m[1]}
+		in.At, _ = strconv.ParseFloat(m[2], 64)
+		in.Clock, _ = strconv.ParseFloat(m[3], 64)
+		in.Bytes, _ = strconv.Atoi(m[4])
+		in.Allocs, _ = strconv.Atoi(m[5])
+		inits = append(inits, in)
+	}
+	return inits, sc.Err()
+}
+
+// Run runs the program at path with args and GODEBUG=inittrace=1, and
+// returns the initializations it reported. The program's own output is
+// discarded.
+func Run(path string, args ...string) ([]Init, error) {
+	cmd := exec.Command(path, args...)
+	godebug := "inittrace=1"
+	if v := os.Getenv("GODEBUG"); v != "" {
+		godebug = v + "," + godebug
+	}
+	cmd.Env = append(os.Environ(), "GODEBUG="+godebug)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %v\n%s", path, err, stderr.Bytes())
+	}
+	return Parse(&stderr)
+}
@@ -0,0 +1,37 @@
+// Package plugins registers itself with package registry when it is
+// initialized. main imports it only for that side effect:
+//
+//	import _ "debugger-lab/01-main-and-entrypoint/internal/plugins"
+package plugins
+
+import (
+	"strings"
+
+	"debugger-lab/01-main-and-entrypoint/internal/config"
+	"debugger-lab/01-main-and-entrypoint/internal/initlog"
+	"debugger-lab/01-main-and-entrypoint/internal/registry"
+)
+
+type greeter struct{}
+
+func (greeter) Name() string { return "greeter" }
+
+func (greeter) Describe() string {
+	if config.Loud {
+		return strings.ToUpper(config.Greeting)
+	}
+	return config.Greeting
+}
+
+type counter struct{ n int }
+
+func (*counter) Name() string { return "counter" }
+
+func (c *counter) Describe() string { return strings.Repeat("*", c.n) }
+
+func init() {
+	initlog.Record("plugins: init")
+	// 👀 config is fully initialized: plugins imports it
+	registry.Register(greeter{})
+	registry.Register(&counter{n: len(config.Name)})
+}
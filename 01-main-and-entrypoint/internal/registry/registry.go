@@ -0,0 +1,41 @@
+// Package registry holds the plugins the program knows about.
+//
+// The plugins import registry to register themselves, so registry cannot
+// import them back: that would be an import cycle, which Go rejects.
+// Instead registry declares what it needs from a plugin as an interface,
+// and any package can provide one.
+package registry
+
+import (
+	"cmp"
+	"slices"
+
+	"debugger-lab/01-main-and-entrypoint/internal/initlog"
+)
+
+// Plugin is implemented by packages registry does not know about.
+type Plugin interface {
+	Name() string
+	Describe() string
+}
+
+var plugins = initlog.Value("registry: var plugins", map[string]Plugin{})
+
+// Register adds p. Plugins call it from their init functions.
+func Register(p Plugin) {
+	// 🔍 SET BREAKPOINT HERE — Called from a plugin's init: main has not started
+	initlog.Record("registry: Register(" + p.Name() + ")")
+	plugins[p.Name()] = p
+}
+
+// All returns the registered plugins sorted by name.
+func All() []Plugin {
+	all := make([]Plugin, 0, len(plugins))
+	for _, p := range plugins {
+		all = append(all, p)
+	}
+	slices.SortFunc(all, func(a, b Plugin) int {
+		return cmp.Compare(a.Name(), b.Name())
+	})
+	return all
+}
@@ -2,25 +2,72 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"os"
+	"strings"
+
+	"debugger-lab/01-main-and-entrypoint/internal/initlog"
+	"debugger-lab/01-main-and-entrypoint/internal/inittrace"
+	"debugger-lab/01-main-and-entrypoint/internal/registry"
+
+	// Imported only so that it initializes and registers its plugins.
+	_ "debugger-lab/01-main-and-entrypoint/internal/plugins"
 )
 
+// modulePath prefixes the import paths of this module's packages.
+const modulePath = "debugger-lab/01-main-and-entrypoint/"
+
 // Global variable initialized before main()
 var globalCounter int
 
 // 🔍 SET BREAKPOINT HERE — init() runs BEFORE main()
 func init() {
 	globalCounter = 100
+	initlog.Record("main: init")
 	fmt.Println("init() called, globalCounter =", globalCounter)
 }
 
 // 🔍 SET BREAKPOINT HERE — Execution enters main() after init()
 func main() {
+	name, args := "", os.Args[1:]
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+	// Only the commands parse flags: any other arguments, -x included,
+	// are just printed below, as os.Args.
+	if name == "-inittrace" {
+		printInitTrace()
+		return
+	}
+	if cmd, ok := commands[name]; ok {
+		// 🔍 SET BREAKPOINT HERE — A command: Step Into (F11) to follow it
+		if err := cmd(args); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		return
+	}
+
 	fmt.Println("main() started")
 
 	// 👀 WATCH globalCounter — it's already been set by init()
 	fmt.Println("globalCounter in main() =", globalCounter)
 
+	// Every package main imports, directly or not, was initialized
+	// first: packages in import path order, as soon as everything
+	// they import is initialized; within a package, variables in
+	// dependency order, then init functions in source order.
+	// 🔍 SET BREAKPOINT HERE — Compare with go run . -inittrace
+	fmt.Println("\nInitialization order, as the program recorded it:")
+	for i, step := range initlog.Steps() {
+		fmt.Printf("%3d. %s\n", i+1, step)
+	}
+	fmt.Println("Plugins registered during initialization:")
+	for _, p := range registry.All() {
+		fmt.Printf("  %s: %s\n", p.Name(), p.Describe())
+	}
+	fmt.Println()
+
 	// Command-line arguments are available via os.Args
 	// 🔍 SET BREAKPOINT HERE — Inspect os.Args in the Variables panel
 	fmt.Println("Program name:", os.Args[0])
@@ -40,3 +87,25 @@ func main() {
 	// 🔍 SET BREAKPOINT HERE — Right before exit
 	fmt.Println("main() finished")
 }
+
+// printInitTrace runs this program again with GODEBUG=inittrace=1 and
+// prints the order in which the runtime initialized its packages, marking
+// this module's with *.
+func printInitTrace() {
+	exe, err := os.Executable()
+	if err != nil {
+		log.Fatal(err)
+	}
+	inits, err := inittrace.Run(exe)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Package initialization order reported by GODEBUG=inittrace=1:")
+	for i, in := range inits {
+		mark := " "
+		if in.Package == "main" || strings.HasPrefix(in.Package, modulePath) {
+			mark = "*"
+		}
+		fmt.Printf("%3d. %s %-56s %7.3f ms clock, %d allocs\n", i+1, mark, in.Package, in.Clock, in.Allocs)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"debugger-lab/01-main-and-entrypoint/internal/config"
+)
+
+// settings is what the greeting commands take from the way the program
+// was invoked.
+type settings struct {
+	Name  string
+	Count int
+	Loud  bool
+}
+
+// keys are the names of the settings: in the config file, after
+// envPrefix in the environment, and as flags.
+var keys = []string{"name", "count", "loud"}
+
+// envPrefix starts the environment variables that hold settings, such
+// as GREET_NAME.
+const envPrefix = "GREET_"
+
+// value is a setting as found in one source, before it is parsed.
+type value struct {
+	raw    string
+	origin string // "default", "greet.conf:2", "$GREET_NAME" or "-name"
+}
+
+// layer maps the keys one source sets to their values.
+type layer map[string]value
+
+// defaults is the lowest layer. config.Name was set during package
+// initialization, before main started.
+func defaults() layer {
+	return layer{
+		"name":  {config.Name, "default"},
+		"count": {"1", "default"},
+		"loud":  {"false", "default"},
+	}
+}
+
+// readFile reads a layer from a file of "key = value" lines. Blank
+// lines and lines starting with # are skipped.
+func readFile(path string) (layer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	l := layer{}
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		origin := fmt.Sprintf("%s:%d", path, n)
+		k, v, ok := strings.Cut(line, "=")
+		k = strings.TrimSpace(k)
+		if !ok || !slices.Contains(keys, k) {
+			return nil, fmt.Errorf("%s: want one of %s = value, got %q", origin, strings.Join(keys, ", "), line)
+		}
+		// 🔍 SET BREAKPOINT HERE — One line of the config file: compare origin with the editor
+		l[k] = value{strings.TrimSpace(v), origin}
+	}
+	return l, sc.Err()
+}
+
+// fromEnv reads a layer from the environment. Unset and empty
+// variables are skipped.
+func fromEnv() layer {
+	l := layer{}
+	for _, k := range keys {
+		name := envPrefix + strings.ToUpper(k)
+		// 👀 WATCH name — os.Getenv("GREET_NAME") in the Debug Console shows what the launch configuration passed
+		if v := os.Getenv(name); v != "" {
+			l[k] = value{v, "$" + name}
+		}
+	}
+	return l
+}
+
+// addFlags defines a flag for each setting on fs and returns a func
+// that reads the layer of the flags given on the command line, once fs
+// has parsed it.
+func addFlags(fs *flag.FlagSet) func() layer {
+	fs.String("name", "", "who to greet")
+	fs.Int("count", 0, "how many times to greet")
+	fs.Bool("loud", false, "greet in capitals")
+	return func() layer {
+		l := layer{}
+		// Visit, unlike VisitAll, skips the flags left at their
+		// default: -count=0 was given, a missing -count was not.
+		fs.Visit(func(f *flag.Flag) {
+			if slices.Contains(keys, f.Name) {
+				l[f.Name] = value{f.Value.String(), "-" + f.Name}
+			}
+		})
+		return l
+	}
+}
+
+// resolve merges layers, the later ones taking precedence, and parses
+// the result. It also returns the merged values, to show where each
+// setting came from.
+func resolve(layers ...layer) (settings, layer, error) {
+	merged := layer{}
+	for _, l := range layers {
+		for k, v := range l {
+			// 🔍 SET CONDITIONAL BREAKPOINT: k == "count"
+			merged[k] = v
+		}
+	}
+
+	var s settings
+	var err error
+	s.Name = merged["name"].raw
+	if s.Count, err = strconv.Atoi(merged["count"].raw); err != nil {
+		return s, merged, fmt.Errorf("count %q from %s is not a number", merged["count"].raw, merged["count"].origin)
+	}
+	if s.Loud, err = strconv.ParseBool(merged["loud"].raw); err != nil {
+		return s, merged, fmt.Errorf("loud %q from %s is not true or false", merged["loud"].raw, merged["loud"].origin)
+	}
+	return s, merged, nil
+}
@@ -0,0 +1,51 @@
+// Package current is the loop-variable demo compiled with this module's
+// language version (go 1.25): since Go 1.22 every iteration of a for
+// loop declares a new i. The code is the same as in ../legacy.
+package current
+
+import (
+	"fmt"
+	"io"
+)
+
+// Loops runs the loop-variable demos, printing to w. Each iteration has
+// its own i.
+func Loops(w io.Writer) {
+	// Loop variable address
+	// 🔍 SET BREAKPOINT HERE
+	addrs := map[*int]bool{}
+	for i := 0; i < 3; i++ {
+		// 👀 Each iteration has its OWN i variable
+		fmt.Fprintf(w, "Loop i=%d, address=%p\n", i, &i)
+		addrs[&i] = true
+	}
+	fmt.Fprintln(w, "Distinct addresses of i:", len(addrs)) // 👀 3: one i per iteration
+
+	// Closure capture
+	// 🔍 SET BREAKPOINT HERE
+	var funcs []func()
+	for i := 0; i < 3; i++ {
+		// Each closure captures its own iteration's i
+		funcs = append(funcs, func() {
+			fmt.Fprintln(w, "Captured i:", i) // 👀 What value will this print?
+		})
+	}
+
+	// 🔍 SET BREAKPOINT HERE — Before calling closures
+	for _, f := range funcs {
+		f() // 🔍 Step Into (F11) here and look at i
+	}
+
+	// The classic fix: shadow the loop variable
+	// 🔍 SET BREAKPOINT HERE
+	var fixed []func()
+	for i := 0; i < 3; i++ {
+		i := i // 👀 Redundant since Go 1.22: i is already per iteration
+		fixed = append(fixed, func() {
+			fmt.Fprintln(w, "Correctly captured i:", i)
+		})
+	}
+	for _, f := range fixed {
+		f()
+	}
+}
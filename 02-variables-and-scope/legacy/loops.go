@@ -0,0 +1,54 @@
+//go:build go1.21
+
+// Package legacy is the loop-variable demo compiled with Go 1.21
+// semantics: the //go:build line above sets this file's language version
+// to 1.21, below the module's go 1.25, so each for loop has ONE i that
+// every iteration reuses. The code is the same as in ../current.
+package legacy
+
+import (
+	"fmt"
+	"io"
+)
+
+// Loops runs the loop-variable demos, printing to w. Each loop has a
+// single i.
+func Loops(w io.Writer) {
+	// Loop variable address
+	// 🔍 SET BREAKPOINT HERE
+	addrs := map[*int]bool{}
+	for i := 0; i < 3; i++ {
+		// ⚠️ Each iteration uses the SAME i variable
+		fmt.Fprintf(w, "Loop i=%d, address=%p\n", i, &i)
+		addrs[&i] = true
+	}
+	fmt.Fprintln(w, "Distinct addresses of i:", len(addrs)) // 👀 1: the loop reused one i
+
+	// Closure capture
+	// 🔍 SET BREAKPOINT HERE
+	var funcs []func()
+	for i := 0; i < 3; i++ {
+		// ⚠️ All closures capture the SAME i variable
+		funcs = append(funcs, func() {
+			fmt.Fprintln(w, "Captured i:", i) // 👀 What value will this print?
+		})
+	}
+
+	// 🔍 SET BREAKPOINT HERE — Before calling closures
+	for _, f := range funcs {
+		f() // 🔍 Step Into (F11) here and look at i
+	}
+
+	// The classic fix: shadow the loop variable
+	// 🔍 SET BREAKPOINT HERE
+	var fixed []func()
+	for i := 0; i < 3; i++ {
+		i := i // 👀 Shadow the loop variable to create a new variable per iteration
+		fixed = append(fixed, func() {
+			fmt.Fprintln(w, "Correctly captured i:", i)
+		})
+	}
+	for _, f := range fixed {
+		f()
+	}
+}
@@ -1,6 +1,16 @@
 package main
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"unsafe"
+
+	"debugger-lab/02-variables-and-scope/current"
+	"debugger-lab/02-variables-and-scope/legacy"
+	"debugger-lab/labkit/sidebyside"
+	"debugger-lab/labkit/where"
+)
 
 func main() {
 	// 🔍 SET BREAKPOINT HERE
@@ -10,53 +20,28 @@ func main() {
 	// Variable shadowing — same name, different variable
 	{
 		// 🔍 SET BREAKPOINT HERE
-		x := 20 // ⚠️ This is a DIFFERENT variable
+		x := 20                    // ⚠️ This is a DIFFERENT variable
 		fmt.Println("Inner x:", x) // 👀 x = 20
 
 		// 👀 WATCH THE ADDRESS of x here vs outer x
-		fmt.Printf("Inner x address: %p\n", &x)
+		fmt.Printf("Inner x address: %#x %s\n", uintptr(unsafe.Pointer(&x)), where.Of(&x))
 	}
 
 	// 🔍 SET BREAKPOINT HERE — Back to outer scope
 	fmt.Println("Outer x again:", x) // 👀 x is still 10
-	fmt.Printf("Outer x address: %p\n", &x)
-
-	// Loop variable shadowing
-	// 🔍 SET BREAKPOINT HERE
-	for i := 0; i < 3; i++ {
-		// Each iteration uses the SAME i variable
-		fmt.Printf("Loop i=%d, address=%p\n", i, &i)
-	}
-
-	// Closure capture gotcha
-	// 🔍 SET BREAKPOINT HERE
-	var funcs []func()
-	for i := 0; i < 3; i++ {
-		// ⚠️ All closures capture the SAME i variable
-		funcs = append(funcs, func() {
-			fmt.Println("Captured i:", i) // 👀 What value will this print?
-		})
-	}
-
-	// 🔍 SET BREAKPOINT HERE — Before calling closures
-	fmt.Println("\nCalling closures:")
-	for _, f := range funcs {
-		f() // ⚠️ What will each function print?
-	}
-
-	// Correct closure capture
-	// 🔍 SET BREAKPOINT HERE
-	var correctFuncs []func()
-	for i := 0; i < 3; i++ {
-		i := i // 👀 Shadow the loop variable to create a new variable per iteration
-		correctFuncs = append(correctFuncs, func() {
-			fmt.Println("Correctly captured i:", i)
-		})
-	}
-
-	// 🔍 SET BREAKPOINT HERE
-	fmt.Println("\nCalling correct closures:")
-	for _, f := range correctFuncs {
-		f()
+	fmt.Printf("Outer x address: %#x %s\n", uintptr(unsafe.Pointer(&x)), where.Of(&x))
+
+	// Loop variables: the same code in two packages. legacy/ is compiled
+	// with Go 1.21 semantics (one i per loop), current/ with this
+	// module's go 1.25 (one i per iteration).
+	// 🔍 SET BREAKPOINT HERE — Step Into (F11) each Loops call
+	var before, after bytes.Buffer
+	legacy.Loops(&before)
+	current.Loops(&after)
+
+	fmt.Println("\n=== Loop Variables Side by Side ===")
+	if err := sidebyside.Print(os.Stdout, "go 1.21 (legacy/)", before.String(), "go 1.25 (current/)", after.String()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 }
@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"flag"
+	"fmt"
+)
 
 // 🔍 SET BREAKPOINT HERE — Third level of the call stack
 func deepFunction(value int) int {
@@ -45,6 +48,13 @@ func factorial(n int) int {
 }
 
 func main() {
+	crash := flag.Bool("overflow", false, "recurse without end under a 1 MB stack limit, and crash")
+	flag.Parse()
+	if *crash {
+		overflow(1 << 20)
+		return
+	}
+
 	fmt.Println("=== Nested Function Calls ===")
 
 	// 🔍 SET BREAKPOINT HERE
@@ -58,6 +68,13 @@ func main() {
 
 	fmt.Println("\n=== Stack Frames Example ===")
 	demonstrateStackFrames()
+
+	fmt.Println("\n=== Stack Growth ===")
+	// 🔍 SET BREAKPOINT HERE — Then step into growStack
+	growStack(10000)
+
+	fmt.Println("\n=== Stack Overflow ===")
+	runOverflow()
 }
 
 // Demonstrates that each function call has its own stack frame
@@ -80,4 +97,7 @@ func helperWithSameName() {
 
 	// 👀 Look at the Call Stack panel
 	// Click on different frames to see different values of 'x'
+
+	fmt.Println("helperWithSameName: how it was called, according to runtime.Callers:")
+	printCallers()
 }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"unsafe"
+)
+
+// growStack recurses depth calls deep on a new goroutine, whose stack
+// starts at a few kilobytes. Each time the stack runs out, the runtime
+// copies it to one twice the size and adjusts every pointer into it, so
+// anchor, a local of the goroutine's first frame, changes address while
+// the goroutine runs.
+func growStack(depth int) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		anchor := 0
+		// 🔍 SET BREAKPOINT HERE — Note &anchor: the stack is still small
+		before := uintptr(unsafe.Pointer(&anchor))
+		copies := descend(&anchor, before, depth)
+		after := uintptr(unsafe.Pointer(&anchor))
+		fmt.Printf("Recursed %d calls deep on a new goroutine\n", depth)
+		fmt.Printf("&anchor before: %#x\n", before)
+		fmt.Printf("&anchor after:  %#x\n", after)
+		fmt.Printf("The stack was copied %d times; anchor moved: %v\n", copies, before != after)
+	}()
+	<-done
+}
+
+// descend recurses depth more times and returns how often p, which
+// points into the bottom frame, changed from last on the way: once per
+// stack copy.
+func descend(p *int, last uintptr, depth int) int {
+	copies := 0
+	if now := uintptr(unsafe.Pointer(p)); now != last {
+		// 🔍 SET BREAKPOINT HERE — The stack was just copied: compare last and now, and depth
+		copies++
+		last = now
+	}
+	if depth == 0 {
+		return copies
+	}
+	return copies + descend(p, last, depth-1)
+}
+
+// overflow recurses without a base case under a stack limit of limit
+// bytes. Passing the limit is not a panic that could be recovered: the
+// runtime prints "goroutine stack exceeds" and "fatal error: stack
+// overflow" and kills the program.
+func overflow(limit int) {
+	debug.SetMaxStack(limit)
+	fmt.Printf("SetMaxStack(%d), then recursing without a base case\n", limit)
+	forever(1)
+}
+
+func forever(n int) int {
+	// 🔍 SET CONDITIONAL BREAKPOINT: n == 1000
+	return forever(n+1) + 1
+}
+
+// runOverflow runs this program again with -overflow and shows how it
+// ended: the runtime's two-line summary, without the stack trace that
+// follows it.
+func runOverflow() {
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Println("cannot find the program:", err)
+		return
+	}
+	cmd := exec.Command(exe, "-overflow")
+	var stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = os.Stdout, &stderr
+	err = cmd.Run()
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if strings.Contains(line, "goroutine stack exceeds") || strings.HasPrefix(line, "fatal error:") {
+			fmt.Println("   ", line)
+		}
+	}
+	fmt.Println("   ", err)
+}
+
+// printCallers prints the calls that led to it, innermost first, as
+// runtime.Callers records them.
+func printCallers() {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(1, pcs) // 1 skips runtime.Callers itself
+	frames := runtime.CallersFrames(pcs[:n])
+	// 🔍 SET BREAKPOINT HERE — Compare the Call Stack panel (or dlv's stack) with what this prints
+	for i := 0; ; i++ {
+		f, more := frames.Next()
+		fmt.Printf("  %d  %-30s %s:%d\n", i, f.Function, filepath.Base(f.File), f.Line)
+		if !more {
+			break
+		}
+	}
+}
@@ -1,3 +1,7 @@
 module debugger-lab/04-pointers-and-memory
 
 go 1.25
+
+require debugger-lab/labkit v0.0.0
+
+replace debugger-lab/labkit => ../labkit
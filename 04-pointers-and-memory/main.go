@@ -1,11 +1,23 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"unsafe"
+
+	"debugger-lab/labkit/where"
+)
+
+// Package variables live in the binary's data segment if they have an
+// initial value, and in its bss segment if they start as zero.
+var (
+	answer = 42
+	unset  int
+)
 
 // Pass by value — the function receives a COPY
 // 🔍 SET BREAKPOINT HERE
 func modifyValue(x int) {
-	fmt.Printf("modifyValue received x=%d at address %p\n", x, &x)
+	fmt.Printf("modifyValue received x=%d at address %#x %s\n", x, uintptr(unsafe.Pointer(&x)), where.Of(&x))
 	x = 999 // This modifies the COPY, not the original
 	fmt.Printf("modifyValue changed x to %d\n", x)
 }
@@ -13,7 +25,7 @@ func modifyValue(x int) {
 // Pass by pointer — the function receives the ADDRESS
 // 🔍 SET BREAKPOINT HERE
 func modifyPointer(x *int) {
-	fmt.Printf("modifyPointer received pointer %p, pointing to value %d\n", x, *x)
+	fmt.Printf("modifyPointer received pointer %#x %s, pointing to value %d\n", uintptr(unsafe.Pointer(x)), where.Of(x), *x)
 	*x = 999 // This modifies the ORIGINAL via the pointer
 	fmt.Printf("modifyPointer changed *x to %d\n", *x)
 }
@@ -23,7 +35,9 @@ func modifyPointer(x *int) {
 // 🔍 SET BREAKPOINT HERE
 func createPointer() *int {
 	local := 42
-	fmt.Printf("createPointer: local=%d at address %p\n", local, &local)
+	// The address is printed as a number: passing &local itself to
+	// Printf would move local to the heap whatever the function returns.
+	fmt.Printf("createPointer: local=%d at address %#x %s\n", local, uintptr(unsafe.Pointer(&local)), where.Of(&local))
 
 	// 👀 Normally, local would be on the stack and disappear after return
 	// But we're returning a pointer to it, so it ESCAPES to the heap
@@ -31,10 +45,11 @@ func createPointer() *int {
 }
 
 // Return a value (stays on stack)
+// 🤔 DOES THIS ESCAPE TO THE HEAP?
 // 🔍 SET BREAKPOINT HERE
 func createValue() int {
 	local := 42
-	fmt.Printf("createValue: local=%d at address %p\n", local, &local)
+	fmt.Printf("createValue: local=%d at address %#x %s\n", local, uintptr(unsafe.Pointer(&local)), where.Of(&local))
 	return local // Just the value is returned, not the address
 }
 
@@ -43,7 +58,7 @@ func main() {
 
 	// 🔍 SET BREAKPOINT HERE
 	original := 100
-	fmt.Printf("Before modifyValue: original=%d at address %p\n", original, &original)
+	fmt.Printf("Before modifyValue: original=%d at address %#x %s\n", original, uintptr(unsafe.Pointer(&original)), where.Of(&original))
 
 	modifyValue(original) // 👀 Watch: original is NOT modified
 
@@ -54,7 +69,7 @@ func main() {
 
 	// 🔍 SET BREAKPOINT HERE
 	original = 100
-	fmt.Printf("Before modifyPointer: original=%d at address %p\n", original, &original)
+	fmt.Printf("Before modifyPointer: original=%d at address %#x %s\n", original, uintptr(unsafe.Pointer(&original)), where.Of(&original))
 
 	modifyPointer(&original) // 👀 Watch: original IS modified
 
@@ -65,7 +80,7 @@ func main() {
 
 	// 🔍 SET BREAKPOINT HERE
 	ptr := createPointer() // 👀 This variable escaped to the heap
-	fmt.Printf("main: received pointer %p, pointing to value %d\n", ptr, *ptr)
+	fmt.Printf("main: received pointer %#x %s, pointing to value %d\n", uintptr(unsafe.Pointer(ptr)), where.Of(ptr), *ptr)
 	// The value still exists even though createPointer() returned!
 
 	// 🔍 SET BREAKPOINT HERE
@@ -79,13 +94,17 @@ func main() {
 	p1 := &x
 	p2 := &x // Both pointers point to the same variable
 
-	fmt.Printf("x=%d at address %p\n", x, &x)
-	fmt.Printf("p1 points to address %p, value=%d\n", p1, *p1)
-	fmt.Printf("p2 points to address %p, value=%d\n", p2, *p2)
+	fmt.Printf("x=%d at address %#x %s\n", x, uintptr(unsafe.Pointer(&x)), where.Of(&x))
+	fmt.Printf("p1 points to address %#x, value=%d\n", uintptr(unsafe.Pointer(p1)), *p1)
+	fmt.Printf("p2 points to address %#x, value=%d\n", uintptr(unsafe.Pointer(p2)), *p2)
 
 	// 🔍 SET BREAKPOINT HERE
 	*p1 = 100 // Modify via p1
 
 	// 👀 Watch: both p1 and p2 see the change, and so does x
-	fmt.Printf("After *p1=100: x=%d, *p1=%d, *p2=%d\n", x, *p1, *p2)
+	fmt.Printf("After *p1=100: x=%d, *p1=%d, *p2=%d\n\n", x, *p1, *p2)
+
+	fmt.Println("=== Package Variables ===")
+	fmt.Printf("answer=%d at address %#x %s\n", answer, uintptr(unsafe.Pointer(&answer)), where.Of(&answer))
+	fmt.Printf("unset=%d at address %#x %s\n", unset, uintptr(unsafe.Pointer(&unset)), where.Of(&unset))
 }
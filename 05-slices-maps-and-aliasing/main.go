@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+)
 
 // Modify a slice passed by value
 // ⚠️ This modifies the BACKING ARRAY, not a copy
@@ -42,6 +45,7 @@ func main() {
 	// 🔍 SET BREAKPOINT HERE
 	aliased := original[1:4] // Shares the backing array with original
 	fmt.Printf("aliased: %v (len=%d, cap=%d)\n", aliased, len(aliased), cap(aliased))
+	drawSlices(os.Stdout, view{"original", original}, view{"aliased", aliased})
 
 	// 👀 Watch both slices in the Variables panel
 	// Modify through the aliased slice
@@ -69,6 +73,7 @@ func main() {
 	// 🔍 SET BREAKPOINT HERE
 	small := []int{1, 2}
 	fmt.Printf("small: %v (len=%d, cap=%d)\n", small, len(small), cap(small))
+	before := small
 
 	// Append without reassigning
 	// 🔍 SET BREAKPOINT HERE — Step into appendToSlice
@@ -80,7 +85,10 @@ func main() {
 	// Append with reassigning
 	// 🔍 SET BREAKPOINT HERE
 	small = appendToSlice(small)
-	fmt.Printf("small after appendToSlice (reassigned): %v\n\n", small)
+	fmt.Printf("small after appendToSlice (reassigned): %v\n", small)
+	// 👀 before is small's old header: a different array
+	drawSlices(os.Stdout, view{"before", before}, view{"small", small})
+	fmt.Println()
 
 	fmt.Println("=== Map Aliasing ===")
 
@@ -104,6 +112,7 @@ func main() {
 	fmt.Printf("src:   %v\n", src)
 	fmt.Printf("alias: %v\n", alias)
 	fmt.Printf("cpy:   %v\n", cpy)
+	drawSlices(os.Stdout, view{"src", src}, view{"alias", alias}, view{"cpy", cpy})
 
 	// 🔍 SET BREAKPOINT HERE
 	src[0] = 999
@@ -112,5 +121,10 @@ func main() {
 	fmt.Printf("After src[0]=999:\n")
 	fmt.Printf("  src:   %v\n", src)
 	fmt.Printf("  alias: %v (changed!)\n", alias)
-	fmt.Printf("  cpy:   %v (unchanged)\n", cpy)
+	fmt.Printf("  cpy:   %v (unchanged)\n\n", cpy)
+
+	fmt.Println("=== Append Growth ===")
+
+	// 🔍 SET BREAKPOINT HERE — Step into growthCurve
+	growthCurve(os.Stdout, 10000)
 }
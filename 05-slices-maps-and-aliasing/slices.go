@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// A slice is a header of three words, a data pointer, a length and a
+// capacity, that describes part of an array:
+//
+//	type slice struct {
+//		array unsafe.Pointer // unsafe.SliceData(s)
+//		len   int
+//		cap   int
+//	}
+//
+// view names a slice for drawSlices.
+type view struct {
+	name string
+	s    []int
+}
+
+const intSize = unsafe.Sizeof(int(0))
+
+// data returns the address of v's first element, which need not be the
+// first element of its array.
+func (v view) data() uintptr {
+	return uintptr(unsafe.Pointer(unsafe.SliceData(v.s)))
+}
+
+// end returns the address just past the last element v can reach by
+// reslicing up to its capacity.
+func (v view) end() uintptr {
+	return v.data() + uintptr(cap(v.s))*intSize
+}
+
+// drawSlices prints the header of every view and, for each array that
+// views point into, a diagram of the array with the indexes through
+// which each view sees its elements:
+//
+//	          +---+---+---+---+---+
+//	array 1   | 1 | 2 | 3 | 4 | 5 |
+//	          +---+---+---+---+---+
+//	original    [0] [1] [2] [3] [4]
+//	aliased         [0] [1] [2]  .
+//
+// A dot is capacity beyond the length: memory the view can grow into
+// without append moving it.
+func drawSlices(w io.Writer, views ...view) {
+	// Views share an array when the memory they can reach overlaps,
+	// directly or through other views: s[0:2] and s[4:6] are drawn on
+	// one array once s[0:6] joins them. group[i] is the first view on
+	// the array of views[i].
+	group := make([]int, len(views))
+	for i, v := range views {
+		group[i] = i
+		for j, u := range views[:i] {
+			if v.data() < u.end() && u.data() < v.end() {
+				from, to := max(group[i], group[j]), min(group[i], group[j])
+				for k := range i + 1 {
+					if group[k] == from {
+						group[k] = to
+					}
+				}
+			}
+		}
+	}
+	var arrays [][]view
+	index := map[int]int{} // group to its index in arrays
+	for i, v := range views {
+		k, ok := index[group[i]]
+		if !ok {
+			k = len(arrays)
+			index[group[i]] = k
+			arrays = append(arrays, nil)
+		}
+		arrays[k] = append(arrays[k], v)
+	}
+
+	for i, a := range arrays {
+		start, end := a[0].data(), a[0].end()
+		for _, v := range a[1:] {
+			start, end = min(start, v.data()), max(end, v.end())
+		}
+		n := int((end - start) / intSize)
+		for _, v := range a {
+			first := int((v.data() - start) / intSize)
+			fmt.Fprintf(w, "%-9s data=%p len=%d cap=%d", v.name, unsafe.SliceData(v.s), len(v.s), cap(v.s))
+			fmt.Fprintf(w, "  → array %d[%d:%d]\n", i+1, first, first+len(v.s))
+		}
+
+		// Every element of the array is in reach of some view.
+		cells := make([]string, n)
+		for _, v := range a {
+			first := int((v.data() - start) / intSize)
+			for j, x := range v.s[:cap(v.s)] {
+				cells[first+j] = strconv.Itoa(x)
+			}
+		}
+		width := 3
+		for _, c := range cells {
+			width = max(width, len(c)+2)
+		}
+		rule := "          +" + strings.Repeat(strings.Repeat("-", width)+"+", n)
+		fmt.Fprintln(w, rule)
+		fmt.Fprintf(w, "%-10s|", fmt.Sprintf("array %d", i+1))
+		for _, c := range cells {
+			fmt.Fprintf(w, "%s|", center(c, width))
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, rule)
+		for _, v := range a {
+			first := int((v.data() - start) / intSize)
+			row := slices.Repeat([]string{""}, n)
+			for j := range cap(v.s) {
+				if j < len(v.s) {
+					row[first+j] = "[" + strconv.Itoa(j) + "]"
+				} else {
+					row[first+j] = "."
+				}
+			}
+			line := fmt.Sprintf("%-11s", v.name)
+			for _, c := range row {
+				line += center(c, width+1)
+			}
+			fmt.Fprintln(w, strings.TrimRight(line, " "))
+		}
+	}
+}
+
+// center pads s with spaces to width, one more on the right when the
+// padding is odd.
+func center(s string, width int) string {
+	pad := max(width-len(s), 0)
+	return strings.Repeat(" ", pad/2) + s + strings.Repeat(" ", pad-pad/2)
+}
+
+// growthCurve appends n ints to a nil slice one at a time and prints a
+// line each time append has to move the slice to a larger array, with
+// how much larger it is. Capacity doubles while it is small, then grows
+// by a smaller factor; the allocator rounds every new array up to one
+// of its size classes, so the factors are not quite regular.
+func growthCurve(w io.Writer, n int) {
+	var s []int
+	fmt.Fprintln(w, "   len  old cap  new cap  factor")
+	for i := range n {
+		old := cap(s)
+		s = append(s, i)
+		if cap(s) == old {
+			continue
+		}
+		// 🔍 SET CONDITIONAL BREAKPOINT: old == 256
+		factor, bar := "-", ""
+		if old > 0 {
+			f := float64(cap(s)) / float64(old)
+			factor = strconv.FormatFloat(f, 'f', 2, 64)
+			bar = strings.Repeat("#", int(f*10+0.5))
+		}
+		line := fmt.Sprintf("%6d  %7d  %7d  %6s  %s", len(s), old, cap(s), factor, bar)
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Named embeds a Counter after a field of its own, so the Counter does
+// not start at the beginning of a Named: &n.Counter is &n plus 16.
+type Named struct {
+	label string
+	Counter
+}
+
+// Shadowed embeds a Counter and declares a value of its own, which
+// hides Counter's value: s.value is Shadowed's, s.Counter.value the
+// one Counter's methods work on.
+type Shadowed struct {
+	Counter
+	value int
+}
+
+// promotion calls Counter's methods on a Named. They are promoted:
+// n.IncrementPointer() is short for (&n.Counter).IncrementPointer(), so
+// the method receives the address of the embedded Counter, not of n.
+func promotion() {
+	n := Named{label: "outer", Counter: Counter{value: 1, name: "embedded"}}
+	fmt.Printf("&n=%#x &n.Counter=%#x (&n + %d, after label)\n", uintptr(unsafe.Pointer(&n)), uintptr(unsafe.Pointer(&n.Counter)), unsafe.Offsetof(n.Counter))
+	// 🔍 SET BREAKPOINT HERE — Step Into (F11): compare c with &n and &n.Counter
+	n.IncrementPointer()
+	n.IncrementValue() // receives a copy of n.Counter, not of n
+	fmt.Printf("n.value=%d (promoted field, same as n.Counter.value=%d)\n", n.value, n.Counter.value)
+}
+
+// shadowing shows that an outer field hides a promoted one of the same
+// name, while promoted methods still see only the embedded struct.
+func shadowing() {
+	s := Shadowed{Counter: Counter{value: 1, name: "inner"}, value: 100}
+	// 🔍 SET BREAKPOINT HERE — Expand s: two fields named value
+	s.IncrementPointer()
+	// 👀 IncrementPointer changed s.Counter.value, not s.value
+	fmt.Printf("s.value=%d s.Counter.value=%d\n", s.value, s.Counter.value)
+}
+
+// methodValues binds methods to c. Binding evaluates the receiver
+// right away: a value receiver is copied into the method value then,
+// and a pointer receiver's address is taken then. Changes to c after
+// binding reach the pointer method, not the value method.
+func methodValues() {
+	c := Counter{value: 10, name: "bound"}
+	byValue := c.IncrementValue     // copies c, with value 10, now
+	byPointer := c.IncrementPointer // stores &c
+	c.value = 20
+	fmt.Printf("c.value=%d after binding\n", c.value)
+	// 🔍 SET BREAKPOINT HERE — Step Into: the Call Stack shows Counter.IncrementValue-fm
+	byValue()
+	byPointer()
+	// 👀 c.value is 21: byValue incremented its copy of 10, byPointer c itself
+	fmt.Printf("c.value=%d after byValue() and byPointer()\n", c.value)
+}
+
+// methodExpressions turns methods into plain functions whose first
+// parameter is the receiver.
+func methodExpressions() {
+	c := Counter{value: 10, name: "expr"}
+	incValue := Counter.IncrementValue
+	incPointer := (*Counter).IncrementPointer
+	fmt.Printf("Counter.IncrementValue is a %T\n", incValue)
+	fmt.Printf("(*Counter).IncrementPointer is a %T\n", incPointer)
+	// 🔍 SET BREAKPOINT HERE — Step Into: the receiver is the first argument
+	incValue(c)
+	incPointer(&c)
+	fmt.Printf("c.value=%d\n", c.value)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unsafe"
+)
+
+// Deliberately badly ordered structs: each small field is followed by
+// a field with a larger alignment, so the compiler pads after it.
+type padded struct {
+	a bool
+	b int64
+	c bool
+	d int32
+	e bool
+}
+
+type record struct {
+	valid bool
+	score float64
+	tag   byte
+	next  *record
+	level uint16
+}
+
+type event struct {
+	active bool
+	id     int64
+	retry  bool
+	name   string
+	count  int16
+}
+
+// field is where a struct field sits, as reflect reports it.
+type field struct {
+	name         string
+	typ          string
+	offset, size uintptr
+	align        uintptr
+}
+
+// fields returns the fields of the struct type t in declaration order.
+func fields(t reflect.Type) []field {
+	fs := make([]field, t.NumField())
+	for i := range fs {
+		f := t.Field(i)
+		fs[i] = field{f.Name, f.Type.String(), f.Offset, f.Type.Size(), uintptr(f.Type.Align())}
+	}
+	return fs
+}
+
+// alignUp rounds n up to a multiple of align, a power of two.
+func alignUp(n, align uintptr) uintptr {
+	return (n + align - 1) &^ (align - 1)
+}
+
+// sizeOf lays fields out the way the compiler does: each field at the
+// next offset that is a multiple of its alignment, and the whole
+// struct rounded up to the largest alignment, so that in an array
+// every element is aligned too.
+func sizeOf(fs []field) uintptr {
+	var off, maxAlign uintptr = 0, 1
+	for _, f := range fs {
+		off = alignUp(off, f.align) + f.size
+		maxAlign = max(maxAlign, f.align)
+	}
+	return alignUp(off, maxAlign)
+}
+
+// printLayout prints the fields of the struct v with their offsets, the
+// padding between them, and an order of the same fields that wastes
+// less.
+func printLayout(v any) {
+	t := reflect.TypeOf(v)
+	fs := fields(t)
+	padding := t.Size()
+	for _, f := range fs {
+		padding -= f.size
+	}
+	fmt.Printf("%s: size %d, align %d, %d bytes of padding\n", t.Name(), t.Size(), t.Align(), padding)
+	fmt.Println("  offset  size  align  field")
+	end := uintptr(0)
+	hole := func(to uintptr) {
+		if to > end {
+			fmt.Printf("  %6d  %4d         (padding)\n", end, to-end)
+		}
+	}
+	for _, f := range fs {
+		hole(f.offset)
+		fmt.Printf("  %6d  %4d  %5d  %s %s\n", f.offset, f.size, f.align, f.name, f.typ)
+		end = f.offset + f.size
+	}
+	hole(t.Size())
+
+	if padding == 0 {
+		return
+	}
+	// Largest alignment first leaves no holes between fields: every
+	// offset is then a multiple of the alignments that follow.
+	sorted := slices.Clone(fs)
+	slices.SortStableFunc(sorted, func(x, y field) int { return cmp.Compare(y.align, x.align) })
+	if size := sizeOf(sorted); size < t.Size() {
+		names := make([]string, len(sorted))
+		for i, f := range sorted {
+			names[i] = f.name + " " + f.typ
+		}
+		fmt.Printf("  reordered by alignment (%s): size %d, saves %d bytes\n", strings.Join(names, ", "), size, t.Size()-size)
+	}
+}
+
+// checkCounter prints what the unsafe functions, evaluated by the
+// compiler, say about Counter: the same numbers reflect found at run
+// time.
+func checkCounter(c Counter) {
+	fmt.Printf("unsafe: Sizeof(c)=%d Alignof(c)=%d Offsetof(c.value)=%d Offsetof(c.name)=%d\n",
+		unsafe.Sizeof(c), unsafe.Alignof(c), unsafe.Offsetof(c.value), unsafe.Offsetof(c.name))
+}
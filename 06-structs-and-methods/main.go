@@ -1,6 +1,11 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"unsafe"
+
+	"debugger-lab/labkit/where"
+)
 
 type Counter struct {
 	value int
@@ -10,17 +15,17 @@ type Counter struct {
 // Value receiver — receives a COPY of the struct
 // 🔍 SET BREAKPOINT HERE
 func (c Counter) IncrementValue() {
-	fmt.Printf("IncrementValue (before): c.value=%d, address=%p\n", c.value, &c)
+	fmt.Printf("IncrementValue (before): c.value=%d, address=%#x %s\n", c.value, uintptr(unsafe.Pointer(&c)), where.Of(&c))
 	c.value++ // This modifies the COPY, not the original
-	fmt.Printf("IncrementValue (after): c.value=%d, address=%p\n", c.value, &c)
+	fmt.Printf("IncrementValue (after): c.value=%d, address=%#x %s\n", c.value, uintptr(unsafe.Pointer(&c)), where.Of(&c))
 }
 
 // Pointer receiver — receives a POINTER to the struct
 // 🔍 SET BREAKPOINT HERE
 func (c *Counter) IncrementPointer() {
-	fmt.Printf("IncrementPointer (before): c.value=%d, address=%p\n", c.value, c)
+	fmt.Printf("IncrementPointer (before): c.value=%d, address=%#x %s\n", c.value, uintptr(unsafe.Pointer(c)), where.Of(c))
 	c.value++ // This modifies the ORIGINAL
-	fmt.Printf("IncrementPointer (after): c.value=%d, address=%p\n", c.value, c)
+	fmt.Printf("IncrementPointer (after): c.value=%d, address=%#x %s\n", c.value, uintptr(unsafe.Pointer(c)), where.Of(c))
 }
 
 // Value receiver that returns the modified struct
@@ -41,7 +46,7 @@ func main() {
 
 	// 🔍 SET BREAKPOINT HERE
 	c1 := Counter{value: 10, name: "c1"}
-	fmt.Printf("Before IncrementValue: c1.value=%d, address=%p\n", c1.value, &c1)
+	fmt.Printf("Before IncrementValue: c1.value=%d, address=%#x %s\n", c1.value, uintptr(unsafe.Pointer(&c1)), where.Of(&c1))
 
 	// 🔍 SET BREAKPOINT HERE — Step Into (F11) to see the copy
 	c1.IncrementValue()
@@ -53,7 +58,7 @@ func main() {
 
 	// 🔍 SET BREAKPOINT HERE
 	c2 := Counter{value: 10, name: "c2"}
-	fmt.Printf("Before IncrementPointer: c2.value=%d, address=%p\n", c2.value, &c2)
+	fmt.Printf("Before IncrementPointer: c2.value=%d, address=%#x %s\n", c2.value, uintptr(unsafe.Pointer(&c2)), where.Of(&c2))
 
 	// 🔍 SET BREAKPOINT HERE — Step Into (F11) to see the pointer
 	c2.IncrementPointer()
@@ -90,8 +95,8 @@ func main() {
 	original := Counter{value: 50, name: "original"}
 	copied := original // This creates a COPY, not an alias
 
-	fmt.Printf("original: value=%d, address=%p\n", original.value, &original)
-	fmt.Printf("copied:   value=%d, address=%p\n", copied.value, &copied)
+	fmt.Printf("original: value=%d, address=%#x %s\n", original.value, uintptr(unsafe.Pointer(&original)), where.Of(&original))
+	fmt.Printf("copied:   value=%d, address=%#x %s\n", copied.value, uintptr(unsafe.Pointer(&copied)), where.Of(&copied))
 
 	// 🔍 SET BREAKPOINT HERE
 	copied.value = 999
@@ -99,5 +104,30 @@ func main() {
 	// 👀 original is unchanged because they're separate structs
 	fmt.Printf("After copied.value=999:\n")
 	fmt.Printf("  original: value=%d\n", original.value)
-	fmt.Printf("  copied:   value=%d\n", copied.value)
+	fmt.Printf("  copied:   value=%d\n\n", copied.value)
+
+	fmt.Println("=== Struct Layout ===")
+
+	printLayout(Counter{})
+	checkCounter(original)
+	fmt.Println()
+	printLayout(padded{})
+	fmt.Println()
+	printLayout(record{})
+	fmt.Println()
+	printLayout(event{})
+
+	bad := padded{a: true, b: 0x1111111111111111, c: true, d: 0x22222222, e: true}
+	// 🔍 SET BREAKPOINT HERE — Examine bad's 32 bytes: x -fmt hex -count 32 -size 1 -x &bad
+	fmt.Printf("\nbad: a=%v b=%#X c=%v d=%#X e=%v\n", bad.a, bad.b, bad.c, bad.d, bad.e)
+
+	fmt.Println("\n=== Embedding ===")
+
+	promotion()
+	shadowing()
+
+	fmt.Println("\n=== Method Values and Expressions ===")
+
+	methodValues()
+	methodExpressions()
 }
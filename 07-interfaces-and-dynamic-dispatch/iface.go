@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"unsafe"
+
+	"debugger-lab/labkit/where"
+)
+
+// An interface value is two words. iface and eface copy the runtime's
+// layout of them, runtime.iface and runtime.eface, as of Go 1.21 to
+// 1.27, to look inside without a debugger. In Delve, the real types are
+// runtime.iface and runtime.eface.
+//
+// An interface with methods, such as Speaker, starts with a pointer to
+// an itab: the dynamic type together with the methods Speaker needs.
+type iface struct {
+	tab  *itab
+	data unsafe.Pointer
+}
+
+// An empty interface, interface{} or any, has no methods to look up,
+// so its first word points to the dynamic type itself.
+type eface struct {
+	typ  *abiType
+	data unsafe.Pointer
+}
+
+// itab copies internal/abi.ITab. The runtime makes one per pair of
+// interface and dynamic type, the compiler most of them in advance.
+type itab struct {
+	inter unsafe.Pointer // *abi.InterfaceType: Speaker
+	typ   *abiType       // the dynamic type
+	hash  uint32         // typ.hash, for type switches
+	fun   [1]uintptr     // the methods of inter, sorted by name; fun[0] == 0 if typ does not implement inter
+}
+
+// abiType copies the start of internal/abi.Type, which describes a type
+// for the runtime. reflect.Type is a pointer to one.
+type abiType struct {
+	size     uintptr
+	ptrBytes uintptr
+	hash     uint32
+}
+
+const badLayout = "this Go version's interface layout is not the one this lab knows"
+
+// words returns the two words of s.
+func words(s Speaker) iface {
+	return *(*iface)(unsafe.Pointer(&s))
+}
+
+// showIface prints the two words of s. The data word of a nil
+// interface is nil and has no tag.
+func showIface(label string, s Speaker) {
+	w := words(s)
+	fmt.Println(label)
+	if w.tab == nil {
+		fmt.Println("  tab  0x0: no itab, so no dynamic type")
+	} else {
+		// Converting to any keeps the data word and replaces the itab
+		// with the type it points to: both must agree with w.
+		a := any(s)
+		e := *(*eface)(unsafe.Pointer(&a))
+		if e.typ != w.tab.typ || e.data != w.data || w.tab.hash != w.tab.typ.hash {
+			fmt.Println(badLayout)
+			return
+		}
+		fmt.Printf("  tab  %#x → itab{type %T, fun[0] %s}\n", uintptr(unsafe.Pointer(w.tab)), s, runtime.FuncForPC(w.tab.fun[0]).Name())
+	}
+	showData(w.data)
+}
+
+// showEface prints the two words of i.
+func showEface(label string, i any) {
+	e := *(*eface)(unsafe.Pointer(&i))
+	fmt.Println(label)
+	switch {
+	case e.typ == nil:
+		fmt.Println("  type 0x0: no dynamic type")
+	case e.typ.size != reflect.TypeOf(i).Size():
+		fmt.Println(badLayout)
+		return
+	default:
+		fmt.Printf("  type %#x → %T, %d bytes\n", uintptr(unsafe.Pointer(e.typ)), i, e.typ.size)
+	}
+	showData(e.data)
+}
+
+// showData prints the data word of an interface, tagged with where it
+// points.
+func showData(data unsafe.Pointer) {
+	if data == nil {
+		fmt.Println("  data 0x0")
+		return
+	}
+	fmt.Printf("  data %#x %s\n", uintptr(data), where.Of((*byte)(data)))
+}
+
+// sameAs reports whether a data word is addr, the address of the
+// variable named v, and so whether the interface holds v itself or a
+// copy of it.
+func sameAs(data, addr unsafe.Pointer, v string) string {
+	if data == addr {
+		return "true: " + v + " itself"
+	}
+	return "false: a copy of " + v
+}
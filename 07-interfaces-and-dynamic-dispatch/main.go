@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"unsafe"
+)
 
 // Interface definition
 type Speaker interface {
@@ -66,9 +69,15 @@ func main() {
 	fmt.Println("\n=== Interface Holding Pointer vs Value ===")
 
 	// 🔍 SET BREAKPOINT HERE
-	s = dog         // Value receiver, interface holds a copy
+	s = dog // Value receiver, interface holds a copy
+	showIface("s = dog", s)
+	fmt.Printf("  data == &dog? %s\n", sameAs(words(s).data, unsafe.Pointer(&dog), "dog"))
+
+	// 🔍 SET BREAKPOINT HERE — Compare s's words with &dog: p *(*runtime.iface)(uintptr(&s))
 	dogPtr := &dog
-	s = dogPtr      // Interface holds a pointer
+	s = dogPtr // Interface holds a pointer
+	showIface("s = dogPtr", s)
+	fmt.Printf("  data == dogPtr? %s\n", sameAs(words(s).data, unsafe.Pointer(dogPtr), "dog"))
 
 	// 👀 Inspect the interface holding pointer vs value
 	fmt.Printf("s (holding *Dog): %v, %T\n\n", s, s)
@@ -83,6 +92,8 @@ func main() {
 	// 👀 Watch these in the Variables panel
 	fmt.Printf("nilInterface: %v, is nil? %v\n", nilInterface, nilInterface == nil)
 	fmt.Printf("nonNilInterface: %v, is nil? %v\n", nonNilInterface, nonNilInterface == nil)
+	showIface("nilInterface", nilInterface)
+	showIface("nonNilInterface", nonNilInterface)
 
 	// ⚠️ nonNilInterface is NOT nil, even though it holds a nil pointer!
 	// This is a common gotcha
@@ -111,6 +122,7 @@ func main() {
 
 // 🔍 SET BREAKPOINT HERE — Observe type switch
 func describeType(i interface{}) {
+	showEface("i", i)
 	// 👀 Watch the runtime type determination
 	switch v := i.(type) {
 	case Dog:
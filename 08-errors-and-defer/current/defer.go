@@ -0,0 +1,48 @@
+// Package current is the defer-in-a-loop demo compiled with this
+// module's language version (go 1.25): since Go 1.22 every iteration of
+// a for loop declares a new i. The code is the same as in ../legacy.
+package current
+
+import (
+	"fmt"
+	"io"
+)
+
+// DeferInLoops runs the three defer-in-a-loop demos, printing to w.
+func DeferInLoops(w io.Writer) {
+	deferArgs(w)
+	deferClosure(w)
+	deferClosureFixed(w)
+}
+
+// deferArgs defers fmt.Fprintln itself. Its arguments, i included, are
+// evaluated when the defer statement runs.
+func deferArgs(w io.Writer) {
+	for i := 0; i < 3; i++ {
+		defer fmt.Fprintln(w, "Deferred i (argument):", i) // 👀 i is evaluated NOW, under any Go version
+	}
+}
+
+// deferClosure defers a closure, which reads i only when it runs, after
+// the loop.
+// 🔍 SET BREAKPOINT HERE
+func deferClosure(w io.Writer) {
+	for i := 0; i < 3; i++ {
+		// 👀 Each deferred closure captures its own iteration's i
+		defer func() {
+			fmt.Fprintln(w, "Deferred closure (loop var) i:", i)
+		}()
+	}
+	// 🔍 SET BREAKPOINT HERE — All defers are scheduled but not run
+}
+
+// deferClosureFixed shadows the loop variable first.
+// 🔍 SET BREAKPOINT HERE
+func deferClosureFixed(w io.Writer) {
+	for i := 0; i < 3; i++ {
+		i := i // Redundant since Go 1.22: i is already per iteration
+		defer func() {
+			fmt.Fprintln(w, "Deferred closure (i := i) i:", i)
+		}()
+	}
+}
@@ -0,0 +1,51 @@
+//go:build go1.21
+
+// Package legacy is the defer-in-a-loop demo compiled with Go 1.21
+// semantics: the //go:build line above sets this file's language version
+// to 1.21, below the module's go 1.25, so each for loop has ONE i that
+// every iteration reuses. The code is the same as in ../current.
+package legacy
+
+import (
+	"fmt"
+	"io"
+)
+
+// DeferInLoops runs the three defer-in-a-loop demos, printing to w.
+func DeferInLoops(w io.Writer) {
+	deferArgs(w)
+	deferClosure(w)
+	deferClosureFixed(w)
+}
+
+// deferArgs defers fmt.Fprintln itself. Its arguments, i included, are
+// evaluated when the defer statement runs.
+func deferArgs(w io.Writer) {
+	for i := 0; i < 3; i++ {
+		defer fmt.Fprintln(w, "Deferred i (argument):", i) // 👀 i is evaluated NOW, under any Go version
+	}
+}
+
+// deferClosure defers a closure, which reads i only when it runs, after
+// the loop.
+// 🔍 SET BREAKPOINT HERE
+func deferClosure(w io.Writer) {
+	for i := 0; i < 3; i++ {
+		// ⚠️ All deferred closures capture the SAME i, which is 3 when they run
+		defer func() {
+			fmt.Fprintln(w, "Deferred closure (loop var) i:", i)
+		}()
+	}
+	// 🔍 SET BREAKPOINT HERE — All defers are scheduled but not run
+}
+
+// deferClosureFixed shadows the loop variable first.
+// 🔍 SET BREAKPOINT HERE
+func deferClosureFixed(w io.Writer) {
+	for i := 0; i < 3; i++ {
+		i := i // Shadow to capture the current value
+		defer func() {
+			fmt.Fprintln(w, "Deferred closure (i := i) i:", i)
+		}()
+	}
+}
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"os"
+
+	"debugger-lab/08-errors-and-defer/current"
+	"debugger-lab/08-errors-and-defer/legacy"
+	"debugger-lab/labkit/sidebyside"
 )
 
 // Function with defer
@@ -73,25 +79,6 @@ func panicAndRecover() {
 	fmt.Println("This never prints")
 }
 
-// Defer with loop variable capture bug
-// 🔍 SET BREAKPOINT HERE
-func deferInLoop() {
-	for i := 0; i < 3; i++ {
-		// ⚠️ All defers will see i = 3 (loop variable capture)
-		defer fmt.Println("Deferred i (buggy):", i)
-	}
-	// 🔍 SET BREAKPOINT HERE — All defers are scheduled but not run
-}
-
-// Correct version
-// 🔍 SET BREAKPOINT HERE
-func deferInLoopFixed() {
-	for i := 0; i < 3; i++ {
-		i := i // Shadow to capture current value
-		defer fmt.Println("Deferred i (fixed):", i)
-	}
-}
-
 func main() {
 	fmt.Println("=== Basic Defer ===")
 
@@ -117,14 +104,17 @@ func main() {
 	panicAndRecover()
 	fmt.Println("Survived the panic!\n")
 
-	fmt.Println("=== Defer in Loop (Buggy) ===")
-
-	// 🔍 SET BREAKPOINT HERE
-	deferInLoop()
-	fmt.Println()
-
-	fmt.Println("=== Defer in Loop (Fixed) ===")
-
-	// 🔍 SET BREAKPOINT HERE
-	deferInLoopFixed()
+	fmt.Println("=== Defer in Loop: go 1.21 vs go 1.25 ===")
+
+	// The same code in two packages: legacy/ is compiled with Go 1.21
+	// semantics (one i per loop), current/ with this module's go 1.25
+	// (one i per iteration).
+	// 🔍 SET BREAKPOINT HERE — Step Into (F11) each DeferInLoops call
+	var before, after bytes.Buffer
+	legacy.DeferInLoops(&before)
+	current.DeferInLoops(&after)
+	if err := sidebyside.Print(os.Stdout, "go 1.21 (legacy/)", before.String(), "go 1.25 (current/)", after.String()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
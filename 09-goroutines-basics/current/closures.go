@@ -0,0 +1,53 @@
+// Package current is the goroutine loop-capture demo compiled with this
+// module's language version (go 1.25): since Go 1.22 every iteration of
+// a for loop declares a new i. The code is the same as in ../legacy.
+package current
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Closures starts a goroutine per iteration of two loops and prints the
+// i each goroutine saw. The goroutines wait until the loop is over, as
+// they usually would anyway, and their values are printed sorted, since
+// goroutines finish in any order.
+func Closures(w io.Writer) {
+	start := make(chan struct{})
+	seen := make(chan int)
+
+	// 🔍 SET BREAKPOINT HERE
+	for i := 0; i < 3; i++ {
+		// 👀 Each goroutine captures its own iteration's i
+		go func() {
+			<-start
+			seen <- i // 👀 Which i does this goroutine read?
+		}()
+	}
+	close(start)
+	printSorted(w, "Goroutine (loop var) i =", seen, 3)
+
+	start = make(chan struct{})
+	// 🔍 SET BREAKPOINT HERE
+	for i := 0; i < 3; i++ {
+		i := i // Redundant since Go 1.22: i is already per iteration
+		go func() {
+			<-start
+			seen <- i
+		}()
+	}
+	close(start)
+	printSorted(w, "Goroutine (i := i) i =", seen, 3)
+}
+
+func printSorted(w io.Writer, label string, seen <-chan int, n int) {
+	var values []int
+	for j := 0; j < n; j++ {
+		values = append(values, <-seen)
+	}
+	slices.Sort(values)
+	for _, v := range values {
+		fmt.Fprintln(w, label, v)
+	}
+}
@@ -0,0 +1,56 @@
+//go:build go1.21
+
+// Package legacy is the goroutine loop-capture demo compiled with Go 1.21
+// semantics: the //go:build line above sets this file's language version
+// to 1.21, below the module's go 1.25, so each for loop has ONE i that
+// every iteration reuses. The code is the same as in ../current.
+package legacy
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Closures starts a goroutine per iteration of two loops and prints the
+// i each goroutine saw. The goroutines wait until the loop is over, as
+// they usually would anyway, and their values are printed sorted, since
+// goroutines finish in any order.
+func Closures(w io.Writer) {
+	start := make(chan struct{})
+	seen := make(chan int)
+
+	// 🔍 SET BREAKPOINT HERE
+	for i := 0; i < 3; i++ {
+		// ⚠️ Closure capture bug (same as module 02): every goroutine shares the one i
+		go func() {
+			<-start
+			seen <- i // 👀 Which i does this goroutine read?
+		}()
+	}
+	close(start)
+	printSorted(w, "Goroutine (loop var) i =", seen, 3)
+
+	start = make(chan struct{})
+	// 🔍 SET BREAKPOINT HERE
+	for i := 0; i < 3; i++ {
+		i := i // Capture value
+		go func() {
+			<-start
+			seen <- i
+		}()
+	}
+	close(start)
+	printSorted(w, "Goroutine (i := i) i =", seen, 3)
+}
+
+func printSorted(w io.Writer, label string, seen <-chan int, n int) {
+	var values []int
+	for j := 0; j < n; j++ {
+		values = append(values, <-seen)
+	}
+	slices.Sort(values)
+	for _, v := range values {
+		fmt.Fprintln(w, label, v)
+	}
+}
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"time"
+
+	"debugger-lab/09-goroutines-basics/current"
+	"debugger-lab/09-goroutines-basics/legacy"
+	"debugger-lab/labkit/sidebyside"
 )
 
 // Simple goroutine function
@@ -65,28 +71,20 @@ func main() {
 	// 👀 What's the final value of counter?
 	fmt.Printf("Final counter: %d\n\n", counter)
 
-	fmt.Println("=== Goroutine with Closure ===")
-
-	// 🔍 SET BREAKPOINT HERE
-	for i := 0; i < 3; i++ {
-		// ⚠️ Closure capture bug (same as module 02)
-		go func() {
-			fmt.Println("Goroutine (buggy) i =", i)
-		}()
-	}
-
-	time.Sleep(50 * time.Millisecond)
-
-	// 🔍 SET BREAKPOINT HERE
-	for i := 0; i < 3; i++ {
-		i := i // Capture value
-		go func() {
-			fmt.Println("Goroutine (fixed) i =", i)
-		}()
+	fmt.Println("=== Goroutine with Closure: go 1.21 vs go 1.25 ===")
+
+	// The same code in two packages: legacy/ is compiled with Go 1.21
+	// semantics (one i per loop), current/ with this module's go 1.25
+	// (one i per iteration).
+	// 🔍 SET BREAKPOINT HERE — Step Into (F11) each Closures call
+	var before, after bytes.Buffer
+	legacy.Closures(&before)
+	current.Closures(&after)
+	if err := sidebyside.Print(os.Stdout, "go 1.21 (legacy/)", before.String(), "go 1.25 (current/)", after.String()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
-	time.Sleep(50 * time.Millisecond)
-
 	fmt.Println("\n=== Anonymous Goroutine ===")
 
 	// 🔍 SET BREAKPOINT HERE
@@ -0,0 +1,3 @@
+module debugger-lab/14-exit-paths
+
+go 1.25
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// scenario is one way for the program to end.
+type scenario struct {
+	name string
+	run  func()
+	doc  string
+}
+
+var scenarios = []scenario{
+	{"exit", osExit, "os.Exit skips every deferred call"},
+	{"fatal", logFatal, "log.Fatal prints, then calls os.Exit(1)"},
+	{"goexit", goexitMain, "runtime.Goexit on the main goroutine"},
+	{"panic", goroutinePanic, "an unrecovered panic in another goroutine"},
+	{"signal", waitForSignal, "SIGINT handled with signal.NotifyContext"},
+}
+
+func main() {
+	name := flag.String("scenario", "", "run the `name`d scenario in this process: exit, fatal, goexit, panic or signal")
+	flag.Parse()
+
+	if *name == "" {
+		// Each scenario ends its process, so each gets a process of its own.
+		for _, s := range scenarios {
+			runChild(s)
+		}
+		return
+	}
+
+	// 👀 Which of the scenarios let this deferred call run?
+	defer fmt.Println("main: deferred call ran")
+	for _, s := range scenarios {
+		if s.name == *name {
+			// 🔍 SET BREAKPOINT HERE — Step into the scenario with F11
+			s.run()
+			fmt.Println("main: scenario returned")
+			return
+		}
+	}
+	log.Fatalf("unknown scenario %q", *name)
+}
+
+// runChild runs this program again with -scenario and reports how the
+// child ended. It interrupts children that wait for a signal. Of a
+// crash, only the first paragraph is shown: the goroutine stacks that
+// follow change from run to run.
+func runChild(s scenario) {
+	fmt.Printf("=== %s: %s\n", s.name, s.doc)
+	exe, err := os.Executable()
+	if err != nil {
+		log.Fatal(err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer r.Close()
+	cmd := exec.Command(exe, "-scenario", s.name)
+	cmd.Stdout, cmd.Stderr = w, w
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			fmt.Println("    (goroutine stacks omitted)")
+			break
+		}
+		fmt.Println("   ", line)
+		if strings.HasPrefix(line, "waiting for SIGINT") {
+			if err := cmd.Process.Signal(os.Interrupt); err != nil {
+				fmt.Println("    cannot send SIGINT here, killing instead:", err)
+				cmd.Process.Kill()
+			}
+		}
+	}
+	io.Copy(io.Discard, r)
+
+	var exit *exec.ExitError
+	switch err := cmd.Wait(); {
+	case err == nil:
+		fmt.Println("    exit status 0")
+	case errors.As(err, &exit):
+		fmt.Printf("    %v\n", exit)
+	default:
+		log.Fatal(err)
+	}
+}
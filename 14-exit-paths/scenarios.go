@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+)
+
+// osExit ends the process on the spot: no deferred call runs, here or
+// in main.
+func osExit() {
+	defer fmt.Println("osExit: deferred call ran")
+	fmt.Println("calling os.Exit(3)")
+	// 🔍 SET BREAKPOINT HERE — Step over: Delve reports "Process N has exited with status 3"
+	os.Exit(3)
+}
+
+// logFatal is osExit with a message: log.Fatal prints to stderr, then
+// calls os.Exit(1).
+func logFatal() {
+	defer fmt.Println("logFatal: deferred call ran")
+	log.SetFlags(0)
+	// 🔍 SET BREAKPOINT HERE — Step into log.Fatal with F11 to find the os.Exit(1)
+	log.Fatal("log.Fatal: cannot go on")
+}
+
+// goexitMain calls runtime.Goexit on the main goroutine. Goexit runs
+// every deferred call of the goroutine, main's included, and ends it
+// without returning. The other goroutines go on; once none is left the
+// runtime reports a deadlock.
+func goexitMain() {
+	done := make(chan struct{})
+	go func() {
+		<-done
+		// 🔍 SET BREAKPOINT HERE — The main goroutine is gone: check the Goroutines panel
+		fmt.Println("worker: still running after the main goroutine exited")
+	}()
+	defer close(done)
+	defer fmt.Println("goexitMain: deferred call ran")
+	// 🔍 SET BREAKPOINT HERE — Continue past the worker: Delve stops at runtime-fatal-throw
+	runtime.Goexit()
+}
+
+// goroutinePanic panics in a goroutine nobody recovers in. The recover
+// in this function does not help: it only stops panics of its own
+// goroutine. The panicking goroutine's deferred calls run; then the
+// whole program exits with status 2.
+func goroutinePanic() {
+	defer func() {
+		fmt.Println("goroutinePanic: recovered", recover())
+	}()
+	done := make(chan struct{})
+	go func() {
+		defer fmt.Println("goroutine: deferred call ran")
+		// 🔍 SET BREAKPOINT HERE — Continue: Delve stops at unrecovered-panic in runtime.fatalpanic
+		panic("boom")
+	}()
+	<-done // never closed: the panic ends the program first
+}
+
+// waitForSignal is the orderly way out: signal.NotifyContext turns
+// SIGINT (Ctrl-C) and SIGTERM into a canceled context, so the program
+// returns from main and every deferred call runs.
+func waitForSignal() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	defer fmt.Println("waitForSignal: deferred call ran")
+
+	// Ctrl-C in a dlv terminal interrupts the debugger, not the program:
+	// send the signal from another terminal with the kill command printed.
+	fmt.Printf("waiting for SIGINT or SIGTERM: kill -INT %d\n", os.Getpid())
+	<-ctx.Done()
+	// 🔍 SET BREAKPOINT HERE — ctx is canceled: print context.Cause(ctx)
+	fmt.Println("context canceled:", context.Cause(ctx))
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// concurrentWrites writes to one map from two goroutines. The runtime
+// flags a map while writing to it; a writer that finds the flag set
+// throws "fatal error: concurrent map writes". A throw is not a panic:
+// no deferred call runs and recover cannot stop it.
+func concurrentWrites() {
+	// With a single P the goroutines would take turns, never writing
+	// at the same time; with two, the kernel interleaves them even on
+	// one CPU.
+	runtime.GOMAXPROCS(max(2, runtime.NumCPU()))
+	m := map[int]int{}
+	fmt.Println("two goroutines write to one map until the runtime notices")
+	var wg sync.WaitGroup
+	for w := range 2 {
+		wg.Go(func() {
+			defer func() {
+				// 👀 Never reached: recover only stops panics
+				fmt.Println("recovered:", recover())
+			}()
+			for i := 0; ; i++ {
+				// 🔍 SET CONDITIONAL BREAKPOINT: i == 1000
+				m[i%64] = w
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,3 @@
+module debugger-lab/15-map-internals
+
+go 1.25
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+)
+
+func main() {
+	order := flag.Bool("order", false, "print the order of one range over fruits and exit")
+	concurrent := flag.Bool("concurrent", false, "write to one map from two goroutines until the runtime notices")
+	flag.Parse()
+
+	switch {
+	case *order:
+		fmt.Println(rangeOrder(fruits()))
+		return
+	case *concurrent:
+		concurrentWrites()
+		return
+	}
+
+	fmt.Println("=== Iteration Order ===")
+	// 🔍 SET BREAKPOINT HERE — Step into iterationOrder
+	iterationOrder()
+
+	fmt.Println("\n=== Mutation During Range ===")
+	deleteDuringRange()
+	addDuringRange()
+
+	fmt.Println("\n=== Growth ===")
+	// 🔍 SET BREAKPOINT HERE — Step into growth, then continue to its conditional breakpoint
+	growth(1000)
+
+	fmt.Println("\n=== Concurrent Writes ===")
+	runChild("-concurrent")
+}
+
+// runChild runs this program again with arg and prints what it printed
+// up to the first blank line, then how it ended. The goroutine stacks
+// of a crash, which follow the blank line, change from run to run.
+func runChild(arg string) {
+	exe, err := os.Executable()
+	if err != nil {
+		log.Fatal(err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer r.Close()
+	cmd := exec.Command(exe, arg)
+	cmd.Stdout, cmd.Stderr = w, w
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			fmt.Println("    (goroutine stacks omitted)")
+			break
+		}
+		fmt.Println("   ", line)
+	}
+	io.Copy(io.Discard, r)
+
+	var exit *exec.ExitError
+	switch err := cmd.Wait(); {
+	case err == nil:
+		fmt.Println("    exit status 0")
+	case errors.As(err, &exit):
+		fmt.Printf("    %v\n", exit)
+	default:
+		log.Fatal(err)
+	}
+}
+
+// childOutput runs this program again with arg and returns what it
+// printed.
+func childOutput(arg string) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	out, err := exec.Command(exe, arg).Output()
+	return string(out), err
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+func fruits() map[string]int {
+	return map[string]int{
+		"apple": 1, "banana": 2, "cherry": 3, "date": 4,
+		"elder": 5, "fig": 6, "grape": 7, "kiwi": 8,
+		"lemon": 9, "mango": 10, "nectarine": 11, "orange": 12,
+	}
+}
+
+// rangeOrder returns the keys of m in the order range produces them.
+func rangeOrder(m map[string]int) string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return strings.Join(keys, " ")
+}
+
+// iterationOrder ranges over the same map three times, then has three
+// new processes range over the same map once each. Every range starts
+// at a random slot, and every process seeds its hashes at random, so
+// no order can be relied on; sort the keys when order matters.
+func iterationOrder() {
+	m := fruits()
+	for i := range 3 {
+		// 🔍 SET BREAKPOINT HERE — Compare m in the Variables panel with what range prints
+		fmt.Printf("range %d: %s\n", i+1, rangeOrder(m))
+	}
+	for i := range 3 {
+		out, err := childOutput("-order")
+		if err != nil {
+			fmt.Printf("run %d: %v\n", i+1, err)
+			continue
+		}
+		fmt.Printf("run %d:   %s", i+1, out)
+	}
+	fmt.Printf("sorted:  %s\n", strings.Join(slices.Sorted(maps.Keys(m)), " "))
+}
+
+// deleteDuringRange deletes every other key at the first step of a
+// range. A key deleted before range reaches it is not produced.
+func deleteDuringRange() {
+	m := fruits()
+	visited := 0
+	for k := range m {
+		visited++
+		for other := range m {
+			if other != k {
+				delete(m, other)
+			}
+		}
+	}
+	// 👀 visited is 1: the other keys were gone before range reached them
+	fmt.Printf("deleting the other keys at the first step: range visited %d of 12 keys\n", visited)
+}
+
+// addDuringRange adds a key for every key it visits. A key added
+// during range may or may not be produced: it depends on where in the
+// table it lands relative to the range's position.
+func addDuringRange() {
+	m := fruits()
+	old, added := 0, 0
+	for k, v := range m {
+		if v > 100 {
+			added++
+			continue
+		}
+		old++
+		// 🔍 SET CONDITIONAL BREAKPOINT: old == 4
+		m[k+"+"] = v + 100
+	}
+	fmt.Printf("adding a key at each step: range visited %d old and %d new keys; len is now %d\n", old, added, len(m))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// Since Go 1.24 a map is a pointer to a Swiss table map, the runtime's
+// internal/runtime/maps.Map. swissMap and swissTable copy the start of
+// its layout, as of Go 1.24 to 1.27, to look inside without a debugger.
+// In Delve, the real types are "internal/runtime/maps".Map and
+// "internal/runtime/maps".table.
+type swissMap struct {
+	used uint64 // len(m)
+	seed uintptr
+	// dirPtr points to the map's only group while the map is small
+	// (dirLen 0); then to a directory of dirLen table pointers.
+	dirPtr      unsafe.Pointer
+	dirLen      int
+	globalDepth uint8 // dirLen is 1 << globalDepth
+}
+
+type swissTable struct {
+	used       uint16
+	capacity   uint16 // slots: 8 per group
+	growthLeft uint16 // inserts before the table grows: it keeps 1/8 of the slots empty
+	localDepth uint8
+	index      int
+	groups     unsafe.Pointer
+	lengthMask uint64 // groups - 1
+}
+
+const groupSlots = 8
+
+// header returns the runtime's map behind m.
+func header(m map[int]int) *swissMap {
+	return *(**swissMap)(unsafe.Pointer(&m))
+}
+
+// tables returns the distinct tables of a map that is not small, in
+// directory order: several directory entries can share one table.
+func (h *swissMap) tables() []*swissTable {
+	var tabs []*swissTable
+	for _, t := range unsafe.Slice((**swissTable)(h.dirPtr), h.dirLen) {
+		if len(tabs) == 0 || tabs[len(tabs)-1] != t {
+			tabs = append(tabs, t)
+		}
+	}
+	return tabs
+}
+
+// describe summarizes the storage of the map behind h.
+func (h *swissMap) describe() string {
+	switch {
+	case h.dirPtr == nil:
+		return "no storage yet"
+	case h.dirLen == 0:
+		return fmt.Sprintf("small map: 1 group, %d slots", groupSlots)
+	}
+	tabs := h.tables()
+	caps := make([]string, len(tabs))
+	for i, t := range tabs {
+		caps[i] = fmt.Sprint(t.capacity)
+	}
+	if len(tabs) == 1 {
+		t := tabs[0]
+		return fmt.Sprintf("1 table: %d groups, %d slots", t.lengthMask+1, t.capacity)
+	}
+	return fmt.Sprintf("%d tables, directory of %d: %s slots", len(tabs), h.dirLen, strings.Join(caps, " + "))
+}
+
+// growth inserts n keys into an empty map and prints the map's storage
+// every time it changes. A small map holds up to 8 entries in a single
+// group. Then the map becomes a table, which doubles when it is 7/8
+// full. A table never grows beyond 1024 slots: it is split in two.
+func growth(n int) {
+	m := map[int]int{}
+	h := header(m)
+	if h.used != 0 {
+		fmt.Println("this Go version's map layout is not the one this lab knows")
+		return
+	}
+	last := ""
+	for i := range n {
+		m[i] = i
+		if h.used != uint64(len(m)) {
+			fmt.Println("this Go version's map layout is not the one this lab knows")
+			return
+		}
+		// 🔍 SET CONDITIONAL BREAKPOINT: len(m) == 9
+		if d := h.describe(); d != last {
+			fmt.Printf("len %4d: %s\n", len(m), d)
+			last = d
+		}
+	}
+}
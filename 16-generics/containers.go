@@ -0,0 +1,58 @@
+package main
+
+// Stack is a last-in, first-out stack of T. Its methods are generic
+// too: Delve names them after the shape, as in
+// main.(*Stack[go.shape.string]).Push.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the top of the stack.
+func (s *Stack[T]) Push(v T) {
+	// 🔍 SET BREAKPOINT HERE — Compare s and v for Stack[string] and Stack[*Task]
+	s.items = append(s.items, v)
+}
+
+// Pop removes and returns the top of the stack, and false if the stack
+// is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	v := s.items[len(s.items)-1]
+	s.items[len(s.items)-1] = zero // let the garbage collector have it
+	s.items = s.items[:len(s.items)-1]
+	return v, true
+}
+
+// Len returns the number of elements on the stack.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Set is a set of T. Map keys must be comparable, so T must be too:
+// comparable is a constraint every type with == satisfies.
+type Set[T comparable] map[T]struct{}
+
+// Add adds v to the set and reports whether it was new.
+func (s Set[T]) Add(v T) bool {
+	if _, ok := s[v]; ok {
+		return false
+	}
+	s[v] = struct{}{}
+	return true
+}
+
+// Has reports whether v is in the set.
+func (s Set[T]) Has(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Task is pushed onto a Stack[*Task]. Every pointer type has the same
+// shape, so Stack[*Task] runs the code of Stack[*int] or any other.
+type Task struct {
+	id   int
+	name string
+}
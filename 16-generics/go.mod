@@ -0,0 +1,3 @@
+module debugger-lab/16-generics
+
+go 1.25
@@ -0,0 +1,51 @@
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("=== FindMax[T cmp.Ordered] ===")
+
+	ints := []int{3, 41, 7, 12}
+	floats := []float64{2.5, 0.5, 9.75}
+	temps := []Celsius{21.5, 36.6, -4}
+	words := []string{"pear", "apple", "quince", "fig"}
+
+	// 🔍 SET BREAKPOINT HERE — Step Into each FindMax and watch the Call Stack
+	maxInt, _ := FindMax(ints)
+	maxFloat, _ := FindMax(floats)
+	maxTemp, _ := FindMax(temps)
+	maxWord, _ := FindMax(words)
+	_, ok := FindMax([]int(nil))
+	fmt.Printf("ints: %d, floats: %v, temps: %v, words: %q, empty: ok=%v\n", maxInt, maxFloat, maxTemp, maxWord, ok)
+
+	fmt.Println("\n=== Constraints ===")
+
+	fmt.Printf("Sum(ints) = %d\n", Sum(ints))
+	// 🔍 SET BREAKPOINT HERE — Step Into Sum[Celsius]: same code as Sum[float64]
+	fmt.Printf("Sum(temps) = %v\n", Sum(temps))
+	fmt.Printf("Hottest(temps) = %s\n", Hottest(temps))
+
+	fmt.Println("\n=== Generic Containers ===")
+
+	var names Stack[string]
+	names.Push("alpha")
+	names.Push("beta")
+	tasks := &Stack[*Task]{}
+	tasks.Push(&Task{id: 1, name: "build"})
+	tasks.Push(&Task{id: 2, name: "test"})
+	// 👀 names.items and tasks.items: Delve shows them as []string and []*main.Task
+	top, _ := names.Pop()
+	next, _ := tasks.Pop()
+	fmt.Printf("names: popped %q, %d left\n", top, names.Len())
+	fmt.Printf("tasks: popped %d %q, %d left\n", next.id, next.name, tasks.Len())
+
+	seen := Set[string]{}
+	for _, w := range []string{"fig", "pear", "fig"} {
+		fmt.Printf("Add(%q): new=%v\n", w, seen.Add(w))
+	}
+	fmt.Printf("Has(\"pear\")=%v Has(\"kiwi\")=%v\n", seen.Has("pear"), seen.Has("kiwi"))
+
+	fmt.Println("\n=== Shapes ===")
+	// 🔍 SET BREAKPOINT HERE — Step into shapes
+	shapes()
+}
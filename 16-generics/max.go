@@ -0,0 +1,73 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// FindMax returns the largest element of s, and false if s is empty.
+// It is the FindMax of 13-debugging-tests for any ordered type, with
+// its off-by-one fixed.
+//
+// The compiler does not write one FindMax per type argument. It writes
+// one per GC shape, the underlying type with every pointer type
+// counted as one, and passes each call a dictionary that says what T
+// is: FindMax[float64] and FindMax[Celsius] run the same code.
+func FindMax[T cmp.Ordered](s []T) (T, bool) {
+	if len(s) == 0 {
+		var zero T
+		return zero, false
+	}
+	// 🔍 SET BREAKPOINT HERE — The Call Stack shows main.FindMax[go.shape.int], not FindMax[int]
+	m := s[0]
+	for _, v := range s[1:] {
+		if v > m {
+			m = v
+		}
+	}
+	return m, true
+}
+
+// Number is a constraint: an interface that only limits type
+// arguments. ~float64 admits every type whose underlying type is
+// float64, such as Celsius, not just float64 itself.
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Sum adds up s.
+func Sum[T Number](s []T) T {
+	var total T
+	for _, v := range s {
+		// 🔍 SET CONDITIONAL BREAKPOINT: total > 50
+		total += v
+	}
+	return total
+}
+
+// Celsius has the underlying type float64: it satisfies cmp.Ordered
+// and Number, and shares its shape with float64.
+type Celsius float64
+
+func (c Celsius) String() string {
+	return fmt.Sprintf("%.1f°C", float64(c))
+}
+
+// OrderedStringer embeds one constraint in another and adds a method:
+// a type argument must be ordered and have a String method.
+type OrderedStringer interface {
+	cmp.Ordered
+	fmt.Stringer
+}
+
+// Hottest returns the largest element of s as a string. The shape
+// code cannot know which String to call: it finds it in the
+// dictionary, the way an interface call finds it in an itab.
+func Hottest[T OrderedStringer](s []T) string {
+	m, ok := FindMax(s)
+	if !ok {
+		return "none"
+	}
+	// 🔍 SET BREAKPOINT HERE — Step Into: String is found through the dictionary
+	return m.String()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Label has the underlying type string, so it has the shape of string.
+type Label string
+
+// codeOf returns the address of the machine code that runs for
+// codeOf[T]. Type arguments with the same shape share that code.
+//
+//go:noinline
+func codeOf[T any]() uintptr {
+	pc, _, _, _ := runtime.Caller(0)
+	return runtime.FuncForPC(pc).Entry()
+}
+
+// nameOf returns the name the runtime gives the code of codeOf[T].
+//
+//go:noinline
+func nameOf[T any]() string {
+	pc, _, _, _ := runtime.Caller(0)
+	return runtime.FuncForPC(pc).Name()
+}
+
+// shapes prints, for pairs of type arguments, whether codeOf runs the
+// same code for both. A shape only looks through the type argument
+// itself: Celsius has the shape float64, but []Celsius is not []float64.
+func shapes() {
+	pairs := []struct {
+		a, b   string
+		ca, cb uintptr
+	}{
+		{"int", "float64", codeOf[int](), codeOf[float64]()},
+		{"float64", "Celsius", codeOf[float64](), codeOf[Celsius]()},
+		{"string", "Label", codeOf[string](), codeOf[Label]()},
+		{"*int", "*Task", codeOf[*int](), codeOf[*Task]()},
+		{"[]float64", "[]Celsius", codeOf[[]float64](), codeOf[[]Celsius]()},
+		{"Task", "struct{ id int; name string }", codeOf[Task](), codeOf[struct {
+			id   int
+			name string
+		}]()},
+	}
+	for _, p := range pairs {
+		same := "different code"
+		if p.ca == p.cb {
+			same = "same code"
+		}
+		fmt.Printf("codeOf[%s] and codeOf[%s]: %s\n", p.a, p.b, same)
+	}
+	// The runtime hides type arguments in names: the [...] stands for
+	// the shape that Delve and go tool nm print.
+	fmt.Printf("runtime name of nameOf[int]: %s\n", nameOf[int]())
+}
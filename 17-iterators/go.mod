@@ -0,0 +1,3 @@
+module debugger-lab/17-iterators
+
+go 1.25
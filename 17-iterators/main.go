@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"iter"
+)
+
+func main() {
+	fmt.Println("=== Push Iterators ===")
+	// 🔍 SET BREAKPOINT HERE — Step into pushLoop
+	pushLoop()
+
+	fmt.Println("\n=== Early Break ===")
+	earlyBreak()
+
+	fmt.Println("\n=== Pull Iterators ===")
+	pullLoop()
+
+	fmt.Println("\n=== Defer in the Loop Body ===")
+	deferInBody()
+
+	fmt.Println("\n=== Panics ===")
+	panicInBody()
+	stubborn()
+}
+
+// pushLoop ranges over Countdown. The body is not inlined into the
+// loop: it runs as yield, called from Countdown's frame.
+func pushLoop() {
+	for v := range Countdown(3) {
+		// 🔍 SET BREAKPOINT HERE — The Call Stack: pushLoop-range1, called by Countdown.func1
+		fmt.Println("  v =", v, "in", stack())
+	}
+}
+
+// earlyBreak runs two separate loops, one over Countdown and one over
+// Fibonacci, and breaks out of each. break makes yield return false.
+func earlyBreak() {
+	for v := range Countdown(5) {
+		fmt.Println("  v =", v)
+		if v == 4 {
+			// 🔍 SET BREAKPOINT HERE — break makes yield return false, then Continue
+			break
+		}
+	}
+	var fibs []int
+	for f := range Fibonacci() {
+		if f > 50 {
+			break
+		}
+		fibs = append(fibs, f)
+	}
+	fmt.Println("  fibs:", fibs)
+}
+
+// pullLoop turns Countdown inside out with iter.Pull: next runs
+// Countdown until it yields a value, and then switches back. Countdown
+// runs in a goroutine of its own, a coroutine.
+func pullLoop() {
+	next, stop := iter.Pull(Traced(Countdown(3)))
+	defer stop()
+	for {
+		// 🔍 SET BREAKPOINT HERE — Step Into next: Countdown runs on another goroutine
+		v, ok := next()
+		if !ok {
+			break
+		}
+		fmt.Println("  next() =", v)
+	}
+	v, ok := next()
+	fmt.Println("  next() after the end =", v, ok)
+
+	// stop ends an iterator that has not finished: yield returns false.
+	next, stop = iter.Pull(Countdown(3))
+	v, _ = next()
+	fmt.Println("  next() =", v, "then stop()")
+	stop()
+}
+
+// deferInBody defers a call in the loop body. The body is a function of
+// its own, but its deferred calls belong to deferInBody: they run when
+// deferInBody returns, as in any other loop.
+func deferInBody() {
+	defer fmt.Println("  deferInBody returns")
+	for v := range Countdown(3) {
+		defer fmt.Println("  deferred in the body, v =", v)
+		fmt.Println("  body, v =", v)
+	}
+	// 👀 The loop is over, and none of the body's deferred calls has run
+	fmt.Println("  after the loop")
+}
+
+// panicInBody panics in the loop body. The panic unwinds through Lines,
+// which closes its file, back to panicInBody, which recovers.
+func panicInBody() {
+	defer func() {
+		fmt.Println("  recovered:", recover())
+	}()
+	for n, line := range Lines("notes.txt") {
+		fmt.Println("  line", n, line)
+		if n == 2 {
+			// 🔍 SET BREAKPOINT HERE — The panic unwinds the frames of the body and of Lines
+			panic("bad line")
+		}
+	}
+}
+
+// stubborn breaks out of a range over Stubborn, which calls yield again
+// anyway. The runtime panics instead of running the body again.
+func stubborn() {
+	defer func() {
+		fmt.Println("  recovered:", recover())
+	}()
+	for v := range Stubborn() {
+		fmt.Println("  v =", v)
+		break
+	}
+	fmt.Println("  not reached")
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"iter"
+	"runtime"
+	"strings"
+)
+
+// Countdown returns an iterator over n, n-1, … 1. It is a push
+// iterator: it runs the loop, and calls yield for every value. In
+//
+//	for v := range Countdown(3) { body }
+//
+// yield is body, compiled into a function of its own. When yield
+// returns false, the body has ended the loop, with break or return,
+// and Countdown must stop.
+func Countdown(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := n; i > 0; i-- {
+			// 🔍 SET BREAKPOINT HERE — Step Into yield: you land in the loop body
+			if !yield(i) {
+				// 🔍 SET BREAKPOINT HERE — The body ended the loop, but the caller has not resumed yet
+				fmt.Println("  Countdown: yield returned false")
+				return
+			}
+		}
+		fmt.Println("  Countdown: done")
+	}
+}
+
+// Fibonacci returns an iterator over the Fibonacci numbers. It never
+// ends on its own: the loop body has to break.
+func Fibonacci() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		a, b := 0, 1
+		for yield(a) {
+			a, b = b, a+b
+		}
+	}
+}
+
+// Lines returns an iterator over the lines of a pretend file, which it
+// opens before the first line and closes however the loop ends.
+func Lines(name string) iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		fmt.Println("  open", name)
+		defer fmt.Println("  close", name)
+		for i, l := range []string{"first", "second", "third"} {
+			if !yield(i+1, l) {
+				return
+			}
+		}
+	}
+}
+
+// Stubborn returns an iterator that ignores yield's result and keeps
+// calling it. The runtime notices on the next call.
+func Stubborn() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := range 3 {
+			// 🔍 SET BREAKPOINT HERE — Step Into yield after the break: it panics
+			yield(i)
+		}
+	}
+}
+
+// Traced returns seq, printing the stack it runs on before it starts.
+func Traced(seq iter.Seq[int]) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		fmt.Println("  iterator runs in", stack())
+		seq(yield)
+	}
+}
+
+// stack returns the functions on the calling goroutine's stack, from
+// the caller of stack outwards, as far as the first one that is neither
+// a closure nor a loop body, or the bottom of the stack.
+func stack() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	var names []string
+	for {
+		f, more := frames.Next()
+		names = append(names, f.Function)
+		if !more || len(names) > 1 && !strings.Contains(f.Function, ".func") && !strings.Contains(f.Function, "-range") {
+			break
+		}
+	}
+	return strings.Join(names, " ← ")
+}
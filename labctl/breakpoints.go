@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"debugger-lab/labctl/internal/markers"
+)
+
+// The root README section between these lines is owned by
+// `labctl breakpoints`.
+const (
+	beginBreakpoints = "<!-- BEGIN BREAKPOINTS: generated by `go run ./labctl breakpoints`; DO NOT EDIT -->"
+	endBreakpoints   = "<!-- END BREAKPOINTS -->"
+)
+
+// runMarkers prints every marker of one module with the line and
+// function it resolves to.
+func runMarkers(e *env, args []string) error {
+	m, rest, err := e.moduleArg(args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("markers takes a single module")
+	}
+	found, err := markers.ParseDir(m.Dir)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "MARKER\tKIND\tSTOPS AT\tFUNCTION\tTEXT")
+	for _, mk := range found {
+		fmt.Fprintf(w, "%s:%d\t%s\t%d\t%s\t%s\n", mk.File, mk.Line, mk.Kind, mk.StmtLine, mk.Func, mk.Text)
+	}
+	return w.Flush()
+}
+
+// runBreakpoints regenerates the "Complete Breakpoint Reference" tables
+// in the root README from the 🔍 SET BREAKPOINT HERE markers. With
+// -check it only reports whether the README is out of date.
+func runBreakpoints(e *env, args []string) error {
+	fs := flag.NewFlagSet("breakpoints", flag.ContinueOnError)
+	check := fs.Bool("check", false, "fail if README.md does not match the markers")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path := filepath.Join(e.root, "README.md")
+	readme, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	tables, err := breakpointTables(e)
+	if err != nil {
+		return err
+	}
+	updated, err := replaceSection(readme, beginBreakpoints, endBreakpoints, tables)
+	if err != nil {
+		return fmt.Errorf("README.md: %w", err)
+	}
+
+	if bytes.Equal(readme, updated) {
+		fmt.Println("README.md breakpoint reference is up to date")
+		return nil
+	}
+	if *check {
+		return errors.New("README.md breakpoint reference is out of date: run go run ./labctl breakpoints")
+	}
+	if err := os.WriteFile(path, updated, 0o644); err != nil {
+		return err
+	}
+	fmt.Println("updated README.md breakpoint reference")
+	return nil
+}
+
+// breakpointTables renders one table per module source file that has
+// breakpoint markers.
+func breakpointTables(e *env) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, m := range e.modules {
+		found, err := markers.ParseDir(m.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Name, err)
+		}
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "### Module %02d: %s\n", m.Number, m.Title)
+
+		file, line := "", 0
+		for _, mk := range found {
+			if mk.Kind != markers.Breakpoint {
+				continue
+			}
+			// A doc-comment marker and a marker inside the body often
+			// land on the same first statement; list it once.
+			if mk.File == file && mk.StmtLine == line {
+				continue
+			}
+			line = mk.StmtLine
+			if mk.File != file {
+				if file != "" {
+					buf.WriteString("\n")
+				}
+				file = mk.File
+				fmt.Fprintf(&buf, "**File:** `%s/%s`\n\n", m.Name, file)
+				buf.WriteString("| Line | Function | Description |\n")
+				buf.WriteString("|------|----------|-------------|\n")
+			}
+			fmt.Fprintf(&buf, "| %d | `%s` | %s |\n", mk.StmtLine, mk.Func, describe(mk))
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// maxStmtLen keeps long Printf calls from stretching the tables.
+const maxStmtLen = 60
+
+// describe is the table text for a breakpoint: the note after the marker
+// or, for a bare marker, the statement it stops on.
+func describe(mk markers.Marker) string {
+	var text string
+	switch {
+	case mk.Condition != "":
+		text = "Conditional: `" + mk.Condition + "`"
+	case mk.Note != "":
+		text = mk.Note
+	default:
+		stmt := []rune(mk.Stmt)
+		if len(stmt) > maxStmtLen {
+			stmt = append(stmt[:maxStmtLen-1], '…')
+		}
+		text = "`" + string(stmt) + "`"
+	}
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+// replaceSection swaps the lines between the begin and end markers of
+// doc for body.
+func replaceSection(doc []byte, begin, end string, body []byte) ([]byte, error) {
+	i := bytes.Index(doc, []byte(begin+"\n"))
+	j := bytes.Index(doc, []byte(end))
+	if i < 0 || j < i {
+		return nil, fmt.Errorf("missing %q ... %q section", begin, end)
+	}
+	var out bytes.Buffer
+	out.Write(doc[:i+len(begin)+1])
+	out.Write(body)
+	out.Write(doc[j:])
+	return out.Bytes(), nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"debugger-lab/labctl/internal/manifest"
+	"debugger-lab/labctl/internal/markers"
+	"debugger-lab/labctl/internal/observe"
+	"debugger-lab/labctl/internal/progress"
+)
+
+// runCheck evaluates the observations in each module's lab.json under
+// Delve and reports every one that no longer holds. Each module's
+// result goes to the progress store.
+func runCheck(e *env, args []string) error {
+	if err := observe.Available(); err != nil {
+		return err
+	}
+	mods, err := e.lookupAll(args)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, m := range mods {
+		man, err := manifest.Load(m)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if len(man.Observations) == 0 {
+			continue
+		}
+		fmt.Println(m.Name)
+		var log bytes.Buffer
+		results, err := observe.Run(m, man, &log)
+		if err != nil {
+			os.Stderr.Write(log.Bytes())
+			return err
+		}
+		check := progress.Check{Module: m.Name, Time: time.Now(), Total: len(results)}
+		for _, r := range results {
+			status, detail := "ok  ", formatGot(r)
+			if r.Failed() {
+				status = "FAIL"
+				failed++
+				if r.Err != nil {
+					detail = r.Err.Error()
+				} else {
+					detail = strings.Join(r.Mismatches(), "; ")
+				}
+				check.Failed = append(check.Failed, fmt.Sprintf("%s: %s", r.Observation, detail))
+			}
+			fmt.Printf("  %s %-40s %-18s %s\n", status, r.Observation, r.Marker.Pos(), detail)
+		}
+		recordProgress(func(s *progress.Store) {
+			s.Checks = append(s.Checks, check)
+		})
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d observation(s) failed", failed)
+	}
+	return nil
+}
+
+func formatGot(r observe.Result) string {
+	var parts []string
+	for expr, v := range r.Got {
+		parts = append(parts, expr+" = "+v)
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, ", ")
+}
+
+// runValidate loads every module's lab.json and checks it against the
+// README title and the markers in the sources.
+func runValidate(e *env, args []string) error {
+	mods, err := e.lookupAll(args)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, m := range mods {
+		man, err := manifest.Load(m)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		found, err := markers.ParseDir(m.Dir)
+		if err != nil {
+			return err
+		}
+		if err := man.Validate(m, found); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Printf("ok  %-36s %2d breakpoints, %d observations, %d questions\n",
+			m.Name, len(man.Breakpoints), len(man.Observations), len(man.Questions))
+	}
+	return errors.Join(errs...)
+}
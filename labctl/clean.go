@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"debugger-lab/labctl/internal/lab"
+)
+
+// runClean deletes the __debug_bin* files Delve leaves behind when a
+// session is killed, plus binaries from `go build` inside a module.
+func runClean(e *env, args []string) error {
+	fs := flag.NewFlagSet("clean", flag.ContinueOnError)
+	dryRun := fs.Bool("n", false, "print the files that would be removed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	mods, err := e.lookupAll(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	removed := 0
+	for _, m := range mods {
+		bins, err := lab.StrayBinaries(m)
+		if err != nil {
+			return err
+		}
+		for _, bin := range bins {
+			rel, _ := filepath.Rel(e.root, bin)
+			if *dryRun {
+				fmt.Println("would remove", rel)
+				continue
+			}
+			if err := os.Remove(bin); err != nil {
+				return err
+			}
+			fmt.Println("removed", rel)
+			removed++
+		}
+	}
+	if !*dryRun && removed == 0 {
+		fmt.Println("nothing to clean")
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/markers"
+)
+
+// dlvInitFile is the Delve init script written into every module.
+const dlvInitFile = "lab.dlv"
+
+// runDlvInit writes lab.dlv into each module so that
+// `dlv debug --init lab.dlv` sets the same breakpoints a student would
+// click in VS Code. With -check it only reports stale scripts.
+func runDlvInit(e *env, args []string) error {
+	fs := flag.NewFlagSet("dlvinit", flag.ContinueOnError)
+	check := fs.Bool("check", false, "fail if a lab.dlv does not match the markers")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	mods, err := e.lookupAll(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for _, m := range mods {
+		found, err := markers.ParseDir(m.Dir)
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Name, err)
+		}
+		script := dlvScript(m, found)
+		path := filepath.Join(m.Dir, dlvInitFile)
+		old, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if bytes.Equal(old, script) {
+			continue
+		}
+		rel := m.Name + "/" + dlvInitFile
+		if *check {
+			stale = append(stale, rel)
+			continue
+		}
+		if err := os.WriteFile(path, script, 0o644); err != nil {
+			return err
+		}
+		fmt.Println("wrote", rel)
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("out of date: %s: run go run ./labctl dlvinit", strings.Join(stale, ", "))
+	}
+	return nil
+}
+
+// dlvScript renders the init script for one module. 🔍 breakpoint
+// markers become named breakpoints; 👀 markers that name variables print
+// them, either when the breakpoint on the same line is hit or from a
+// tracepoint that does not stop.
+func dlvScript(m lab.Module, found []markers.Marker) []byte {
+	mode := "debug"
+	if !m.IsMain() {
+		mode = "test"
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Delve init script for %s, generated from the 🔍 and 👀\n", m.Name)
+	fmt.Fprintf(&buf, "# markers by `go run ./labctl dlvinit`. DO NOT EDIT.\n")
+	fmt.Fprintf(&buf, "#\n")
+	fmt.Fprintf(&buf, "#   cd %s\n", m.Name)
+	fmt.Fprintf(&buf, "#   dlv %s --build-flags=\"-gcflags='%s'\" --init %s\n", mode, lab.DebugGCFlags, dlvInitFile)
+	fmt.Fprintf(&buf, "#\n")
+	fmt.Fprintf(&buf, "# Then type `continue` to run to the first breakpoint.\n")
+
+	names := map[string]string{} // file:line → breakpoint name
+	bps, watches := 0, 0
+	for _, mk := range found {
+		if mk.Func == "" {
+			continue
+		}
+		loc := mk.Pos()
+		switch {
+		case mk.Kind == markers.Breakpoint:
+			name, ok := names[loc]
+			fmt.Fprintf(&buf, "\n# %s:%d 🔍 %s\n", mk.File, mk.Line, mk.Text)
+			if !ok {
+				bps++
+				name = fmt.Sprintf("bp%d", bps)
+				names[loc] = name
+				fmt.Fprintf(&buf, "break %s %s\n", name, loc)
+			}
+			if mk.Condition != "" {
+				fmt.Fprintf(&buf, "condition %s %s\n", name, mk.Condition)
+			}
+		case mk.Kind == markers.Watch && len(mk.Vars) > 0:
+			fmt.Fprintf(&buf, "\n# %s:%d 👀 %s\n", mk.File, mk.Line, mk.Text)
+			name, ok := names[loc]
+			if !ok {
+				watches++
+				name = fmt.Sprintf("watch%d", watches)
+				names[loc] = name
+				fmt.Fprintf(&buf, "break %s %s\n", name, loc)
+				fmt.Fprintf(&buf, "on %s trace\n", name)
+			}
+			for _, v := range mk.Vars {
+				fmt.Fprintf(&buf, "on %s print %s\n", name, v)
+			}
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"debugger-lab/labctl/internal/escape"
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/manifest"
+	"debugger-lab/labctl/internal/markers"
+)
+
+// runEscape builds modules with -gcflags=-m=2 and prints, under each 🤔
+// marker, where the compiler put every variable of the marker's
+// function and why. It fails if an escape claim in a lab.json
+// contradicts the compiler. With -readme it instead rewrites the
+// escapes section of each module README from the claims.
+func runEscape(e *env, args []string) error {
+	fs := flag.NewFlagSet("escape", flag.ContinueOnError)
+	readme := fs.Bool("readme", false, "rewrite the README escapes sections from lab.json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	mods, err := e.lookupAll(fs.Args())
+	if err != nil {
+		return err
+	}
+	if *readme {
+		return writeEscapes(mods)
+	}
+
+	failed := 0
+	for _, m := range mods {
+		found, err := markers.ParseDir(m.Dir)
+		if err != nil {
+			return err
+		}
+		man, err := manifest.Load(m)
+		if err != nil {
+			return err
+		}
+		var questions []markers.Marker
+		for _, mk := range found {
+			if mk.Kind == markers.Question && mk.Func != "" {
+				questions = append(questions, mk)
+			}
+		}
+		if len(questions) == 0 && len(man.Escapes) == 0 {
+			continue
+		}
+		rep, err := escape.Build(m)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(m.Name)
+		for _, mk := range questions {
+			fmt.Printf("  %s:%d 🤔 %s (%s)\n", mk.File, mk.Line, mk.Text, mk.Func)
+			vars, err := escape.Vars(m, mk, rep)
+			if err != nil {
+				return err
+			}
+			for _, v := range vars {
+				fmt.Printf("    %-10s %-5s %s\n", v.Name, v.Where(), v.Pos)
+				for _, line := range v.Flow {
+					fmt.Printf("      %s\n", line)
+				}
+			}
+		}
+		for _, r := range escape.Check(m, man, found, rep) {
+			status, detail := "ok  ", "compiler agrees"
+			switch {
+			case r.Err != nil:
+				status, detail = "FAIL", r.Err.Error()
+			case r.Failed():
+				status, detail = "FAIL", fmt.Sprintf("compiler puts %s on the %s", r.Var.Name, r.Var.Where())
+			}
+			if r.Failed() {
+				failed++
+			}
+			fmt.Printf("  %s %s: %s\n", status, r.Claim, detail)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d escape claim(s) contradict the compiler", failed)
+	}
+	return nil
+}
+
+// writeEscapes replaces the escapes section of the README of every
+// module in mods that has escape claims.
+func writeEscapes(mods []lab.Module) error {
+	for _, m := range mods {
+		man, err := manifest.Load(m)
+		if err != nil {
+			return err
+		}
+		if len(man.Escapes) == 0 {
+			continue
+		}
+		path := filepath.Join(m.Dir, "README.md")
+		readme, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		updated, err := replaceSection(readme, manifest.BeginEscapes, manifest.EndEscapes, manifest.EscapeText(man.Escapes))
+		if err != nil {
+			return fmt.Errorf("%s/README.md: %w", m.Name, err)
+		}
+		if bytes.Equal(readme, updated) {
+			fmt.Printf("%s/README.md escapes are up to date\n", m.Name)
+			continue
+		}
+		if err := os.WriteFile(path, updated, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("updated %s/README.md escapes\n", m.Name)
+	}
+	return nil
+}
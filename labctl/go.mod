@@ -0,0 +1,3 @@
+module debugger-lab/labctl
+
+go 1.25
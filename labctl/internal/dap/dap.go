@@ -0,0 +1,167 @@
+// Package dap is a minimal Debug Adapter Protocol client for `dlv dap`,
+// the adapter the VS Code Go extension talks to. It covers what a
+// scripted lab walkthrough needs: launch, breakpoints, stepping, and
+// reading the scopes and variables the Variables panel shows.
+//
+// See https://microsoft.github.io/debug-adapter-protocol/specification.
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os/exec"
+	"strconv"
+
+	"debugger-lab/labctl/internal/dlv"
+)
+
+const listeningPrefix = "DAP server listening at: "
+
+// Start runs `dlv dap` on a free loopback port in dir and returns once
+// it is listening. Delve's own output goes to log.
+func Start(dir string, log io.Writer) (*dlv.Server, error) {
+	cmd := exec.Command("dlv", "dap", "--listen=127.0.0.1:0")
+	cmd.Dir = dir
+	return dlv.Listen(cmd, listeningPrefix, log)
+}
+
+// message is the union of DAP requests, responses and events.
+type message struct {
+	Seq        int             `json:"seq"`
+	Type       string          `json:"type"`
+	Command    string          `json:"command,omitempty"`
+	Arguments  any             `json:"arguments,omitempty"`
+	RequestSeq int             `json:"request_seq,omitempty"`
+	Success    bool            `json:"success,omitempty"`
+	Message    string          `json:"message,omitempty"`
+	Event      string          `json:"event,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+}
+
+// Event is a DAP event the client has received but not yet consumed.
+type Event struct {
+	Event string
+	Body  json.RawMessage
+}
+
+// Client is a DAP session with one adapter.
+type Client struct {
+	conn    net.Conn
+	r       *bufio.Reader
+	seq     int
+	pending []Event
+	// Output receives the debuggee's stdout and stderr, which DAP
+	// delivers as "output" events.
+	Output io.Writer
+}
+
+// Dial connects to a DAP server.
+func Dial(addr string) (*Client, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, r: bufio.NewReader(conn), Output: io.Discard}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) send(m message) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.conn, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	return err
+}
+
+func (c *Client) read() (message, error) {
+	header, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	if err != nil {
+		return message{}, err
+	}
+	n, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return message{}, fmt.Errorf("bad DAP header: %v", header)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(c.r, data); err != nil {
+		return message{}, err
+	}
+	var m message
+	err = json.Unmarshal(data, &m)
+	return m, err
+}
+
+// call sends a request and waits for its response, queueing any events
+// that arrive meanwhile. The response body is decoded into out.
+func (c *Client) call(command string, args, out any) error {
+	c.seq++
+	seq := c.seq
+	if err := c.send(message{Seq: seq, Type: "request", Command: command, Arguments: args}); err != nil {
+		return err
+	}
+	for {
+		m, err := c.read()
+		if err != nil {
+			return err
+		}
+		switch {
+		case m.Type == "event":
+			c.queue(m)
+		case m.Type == "response" && m.RequestSeq == seq:
+			if !m.Success {
+				return fmt.Errorf("%s: %s", command, m.Message)
+			}
+			if out == nil || len(m.Body) == 0 {
+				return nil
+			}
+			return json.Unmarshal(m.Body, out)
+		}
+	}
+}
+
+// queue keeps an event for WaitEvent, printing output events right away.
+func (c *Client) queue(m message) {
+	if m.Event == "output" {
+		var body struct {
+			Category string `json:"category"`
+			Output   string `json:"output"`
+		}
+		if json.Unmarshal(m.Body, &body) == nil && body.Category != "telemetry" {
+			io.WriteString(c.Output, body.Output)
+		}
+		return
+	}
+	c.pending = append(c.pending, Event{Event: m.Event, Body: m.Body})
+}
+
+// WaitEvent returns the next queued or incoming event named one of
+// names, dropping events with other names.
+func (c *Client) WaitEvent(names ...string) (Event, error) {
+	for {
+		for len(c.pending) > 0 {
+			e := c.pending[0]
+			c.pending = c.pending[1:]
+			for _, n := range names {
+				if e.Event == n {
+					return e, nil
+				}
+			}
+		}
+		m, err := c.read()
+		if err != nil {
+			return Event{}, err
+		}
+		if m.Type == "event" {
+			c.queue(m)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeAdapter answers requests on conn from a table of response bodies
+// keyed by command, and sends the events listed for a command right
+// before its response.
+func fakeAdapter(t *testing.T, conn net.Conn, bodies map[string]any, events map[string][]message) {
+	r := bufio.NewReader(conn)
+	seq := 0
+	write := func(m message) {
+		seq++
+		m.Seq = seq
+		data, _ := json.Marshal(m)
+		fmt.Fprintf(conn, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	}
+	for {
+		header, err := textproto.NewReader(r).ReadMIMEHeader()
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(header.Get("Content-Length"))
+		data := make([]byte, n)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return
+		}
+		var req message
+		if err := json.Unmarshal(data, &req); err != nil {
+			t.Errorf("adapter: %v", err)
+			return
+		}
+		for _, e := range events[req.Command] {
+			write(e)
+		}
+		body, _ := json.Marshal(bodies[req.Command])
+		write(message{Type: "response", RequestSeq: req.Seq, Command: req.Command, Success: req.Command != "fail", Message: "boom", Body: body})
+	}
+}
+
+func TestSession(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	bodies := map[string]any{
+		"stackTrace": map[string]any{"stackFrames": []map[string]any{
+			{"id": 1000, "name": "main.main", "line": 7, "source": map[string]string{"path": "/lab/02/main.go"}},
+		}},
+		"scopes": map[string]any{"scopes": []map[string]any{
+			{"name": "Locals", "variablesReference": 1},
+			{"name": "Registers", "variablesReference": 9, "expensive": true},
+		}},
+	}
+	event := func(name string, body any) message {
+		data, _ := json.Marshal(body)
+		return message{Type: "event", Event: name, Body: data}
+	}
+	events := map[string][]message{
+		"launch": {event("output", map[string]string{"category": "stdout", "output": "hello\n"}), event("initialized", nil)},
+		"continue": {
+			event("stopped", map[string]any{"reason": "breakpoint", "threadId": 1, "hitBreakpointIds": []int{3}}),
+		},
+		"next": {event("terminated", nil)},
+	}
+	go fakeAdapter(t, b, bodies, events)
+
+	var out strings.Builder
+	c := &Client{conn: a, r: bufio.NewReader(a), Output: &out}
+	if err := c.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Launch(LaunchArgs{Mode: "debug", Program: "/lab/02"}); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "hello\n" {
+		t.Errorf("output = %q, want %q", out.String(), "hello\n")
+	}
+
+	stop, err := c.Continue(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Stop{Reason: "breakpoint", ThreadID: 1, HitBreakpointIDs: []int{3}}
+	if !reflect.DeepEqual(stop, want) {
+		t.Errorf("Continue = %+v, want %+v", stop, want)
+	}
+
+	// The fake answers "variables" with no body, so Locals is empty; the
+	// expensive Registers scope must not be read at all.
+	f, err := c.Snapshot(1, 2, "/lab/02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Function != "main.main" || f.File != "main.go" || f.Line != 7 || len(f.Scopes) != 1 || f.Scopes[0].Name != "Locals" {
+		t.Errorf("Snapshot = %+v", f)
+	}
+
+	stop, err = c.Next(1)
+	if err != nil || !stop.Terminated {
+		t.Errorf("Next = %+v, %v; want terminated", stop, err)
+	}
+
+	if err := c.call("fail", nil, nil); err == nil || err.Error() != "fail: boom" {
+		t.Errorf("failed request: err = %v, want fail: boom", err)
+	}
+}
+
+func TestMaskAddresses(t *testing.T) {
+	f := Frame{Scopes: []ScopeView{{Name: "Locals", Variables: []Node{
+		{Name: "p", Value: "*10", Children: []Node{{Name: "", Value: "(*int)(0xc000012345)"}}},
+		{Name: "x", Value: "10"},
+	}}}}
+	f.MaskAddresses()
+	if got := f.Scopes[0].Variables[0].Children[0].Value; got != "(*int)(0x…)" {
+		t.Errorf("masked value = %q", got)
+	}
+	if got := f.Scopes[0].Variables[1].Value; got != "10" {
+		t.Errorf("plain value = %q", got)
+	}
+}
@@ -0,0 +1,101 @@
+package dap
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+// Frame is the Variables panel for the top frame at a stop: every scope
+// with its variables expanded to a fixed depth, the way a student sees
+// it after clicking each ▸.
+type Frame struct {
+	Function string      `json:"function"`
+	File     string      `json:"file"`
+	Line     int         `json:"line"`
+	Scopes   []ScopeView `json:"scopes"`
+}
+
+// ScopeView is a scope and its variables.
+type ScopeView struct {
+	Name      string `json:"name"`
+	Variables []Node `json:"variables"`
+}
+
+// Node is a variable and, if it was expanded, its children.
+type Node struct {
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"`
+	Value    string `json:"value"`
+	Children []Node `json:"children,omitempty"`
+}
+
+// Snapshot reads the Variables panel for the top frame of threadID,
+// expanding variables depth levels deep. File paths are made relative
+// to dir.
+func (c *Client) Snapshot(threadID, depth int, dir string) (Frame, error) {
+	frames, err := c.StackTrace(threadID, 1)
+	if err != nil || len(frames) == 0 {
+		return Frame{}, err
+	}
+	top := frames[0]
+	f := Frame{Function: top.Name, Line: top.Line}
+	if top.Source != nil {
+		f.File = top.Source.Path
+		if rel, err := filepath.Rel(dir, top.Source.Path); err == nil {
+			f.File = filepath.ToSlash(rel)
+		}
+	}
+
+	scopes, err := c.Scopes(top.ID)
+	if err != nil {
+		return f, err
+	}
+	for _, s := range scopes {
+		if s.Expensive {
+			continue
+		}
+		vars, err := c.expand(s.VariablesReference, depth)
+		if err != nil {
+			return f, err
+		}
+		f.Scopes = append(f.Scopes, ScopeView{Name: s.Name, Variables: vars})
+	}
+	return f, nil
+}
+
+func (c *Client) expand(ref, depth int) ([]Node, error) {
+	vars, err := c.Variables(ref)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]Node, 0, len(vars))
+	for _, v := range vars {
+		n := Node{Name: v.Name, Type: v.Type, Value: v.Value}
+		if v.VariablesReference > 0 && depth > 1 {
+			if n.Children, err = c.expand(v.VariablesReference, depth-1); err != nil {
+				return nil, err
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+var addrRe = regexp.MustCompile(`0x[0-9a-f]{6,}`)
+
+// MaskAddresses replaces pointer values in f with "0x…", so snapshots
+// taken in different runs or with different Go versions differ only
+// where the values students see differ.
+func (f *Frame) MaskAddresses() {
+	for i := range f.Scopes {
+		maskNodes(f.Scopes[i].Variables)
+	}
+}
+
+func maskNodes(nodes []Node) {
+	for i := range nodes {
+		nodes[i].Value = addrRe.ReplaceAllString(nodes[i].Value, "0x…")
+		nodes[i].Name = addrRe.ReplaceAllString(nodes[i].Name, "0x…")
+		maskNodes(nodes[i].Children)
+	}
+}
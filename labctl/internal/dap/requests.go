@@ -0,0 +1,195 @@
+package dap
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LaunchArgs are the launch request arguments dlv dap understands, in the
+// shape .vscode/launch.json uses.
+type LaunchArgs struct {
+	Mode                string   `json:"mode"`    // "debug" or "test"
+	Program             string   `json:"program"` // package directory
+	Args                []string `json:"args,omitempty"`
+	Cwd                 string   `json:"cwd,omitempty"`
+	BuildFlags          string   `json:"buildFlags,omitempty"`
+	StopOnEntry         bool     `json:"stopOnEntry,omitempty"`
+	ShowGlobalVariables bool     `json:"showGlobalVariables,omitempty"`
+}
+
+// SourceBreakpoint is a breakpoint to set in one file.
+type SourceBreakpoint struct {
+	Line      int    `json:"line"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// Breakpoint is a breakpoint as the adapter set it.
+type Breakpoint struct {
+	ID       int    `json:"id"`
+	Verified bool   `json:"verified"`
+	Line     int    `json:"line"`
+	Message  string `json:"message,omitempty"`
+}
+
+// Source is a file in a stack frame.
+type Source struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// StackFrame is one frame of a thread's stack.
+type StackFrame struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Source *Source `json:"source,omitempty"`
+	Line   int     `json:"line"`
+}
+
+// Scope is a group of variables in a frame, such as Locals or Globals.
+type Scope struct {
+	Name               string `json:"name"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+// Variable is one row of the Variables panel. A nonzero
+// VariablesReference means it can be expanded.
+type Variable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type"`
+	EvaluateName       string `json:"evaluateName,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+// Stop describes why the debuggee stopped. Terminated is set instead
+// when it exited.
+type Stop struct {
+	Reason            string `json:"reason"`
+	ThreadID          int    `json:"threadId"`
+	Description       string `json:"description,omitempty"`
+	HitBreakpointIDs  []int  `json:"hitBreakpointIds,omitempty"`
+	AllThreadsStopped bool   `json:"allThreadsStopped,omitempty"`
+	Terminated        bool   `json:"-"`
+}
+
+// Initialize performs the initialize handshake.
+func (c *Client) Initialize() error {
+	return c.call("initialize", map[string]any{
+		"clientID":        "labctl",
+		"adapterID":       "go",
+		"pathFormat":      "path",
+		"linesStartAt1":   true,
+		"columnsStartAt1": true,
+	}, nil)
+}
+
+// Launch builds and starts the program, then waits for the adapter's
+// initialized event, after which breakpoints may be set.
+func (c *Client) Launch(args LaunchArgs) error {
+	if err := c.call("launch", args, nil); err != nil {
+		return err
+	}
+	_, err := c.WaitEvent("initialized")
+	return err
+}
+
+// SetBreakpoints replaces all breakpoints in the file at path.
+func (c *Client) SetBreakpoints(path string, bps []SourceBreakpoint) ([]Breakpoint, error) {
+	var out struct {
+		Breakpoints []Breakpoint `json:"breakpoints"`
+	}
+	if bps == nil {
+		bps = []SourceBreakpoint{}
+	}
+	err := c.call("setBreakpoints", map[string]any{
+		"source":      Source{Path: path},
+		"breakpoints": bps,
+	}, &out)
+	return out.Breakpoints, err
+}
+
+// ConfigurationDone tells the adapter that breakpoints are set; unless
+// the launch asked to stop on entry, the program starts running.
+func (c *Client) ConfigurationDone() error {
+	return c.call("configurationDone", nil, nil)
+}
+
+// Wait blocks until the debuggee stops or terminates.
+func (c *Client) Wait() (Stop, error) {
+	e, err := c.WaitEvent("stopped", "terminated")
+	if err != nil {
+		return Stop{}, err
+	}
+	if e.Event == "terminated" {
+		return Stop{Terminated: true}, nil
+	}
+	var s Stop
+	err = json.Unmarshal(e.Body, &s)
+	return s, err
+}
+
+// Continue resumes all threads and waits for the next stop.
+func (c *Client) Continue(threadID int) (Stop, error) {
+	return c.resume("continue", threadID)
+}
+
+// Next steps over the current line.
+func (c *Client) Next(threadID int) (Stop, error) {
+	return c.resume("next", threadID)
+}
+
+// StepIn steps into the call on the current line.
+func (c *Client) StepIn(threadID int) (Stop, error) {
+	return c.resume("stepIn", threadID)
+}
+
+// StepOut runs until the current function returns.
+func (c *Client) StepOut(threadID int) (Stop, error) {
+	return c.resume("stepOut", threadID)
+}
+
+func (c *Client) resume(command string, threadID int) (Stop, error) {
+	if err := c.call(command, map[string]int{"threadId": threadID}, nil); err != nil {
+		return Stop{}, err
+	}
+	return c.Wait()
+}
+
+// StackTrace returns up to levels frames of a thread; 0 means all.
+func (c *Client) StackTrace(threadID, levels int) ([]StackFrame, error) {
+	var out struct {
+		StackFrames []StackFrame `json:"stackFrames"`
+	}
+	err := c.call("stackTrace", map[string]int{"threadId": threadID, "levels": levels}, &out)
+	return out.StackFrames, err
+}
+
+// Scopes returns the scopes of a frame.
+func (c *Client) Scopes(frameID int) ([]Scope, error) {
+	var out struct {
+		Scopes []Scope `json:"scopes"`
+	}
+	err := c.call("scopes", map[string]int{"frameId": frameID}, &out)
+	return out.Scopes, err
+}
+
+// Variables returns the children of a scope or expandable variable.
+func (c *Client) Variables(ref int) ([]Variable, error) {
+	var out struct {
+		Variables []Variable `json:"variables"`
+	}
+	err := c.call("variables", map[string]int{"variablesReference": ref}, &out)
+	return out.Variables, err
+}
+
+// Disconnect ends the session; with terminate it also kills the
+// debuggee, after which dlv dap exits.
+func (c *Client) Disconnect(terminate bool) error {
+	err := c.call("disconnect", map[string]bool{"terminateDebuggee": terminate}, nil)
+	c.Close()
+	if err != nil {
+		return fmt.Errorf("disconnect: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package dlv
+
+import "reflect"
+
+// The types below mirror the subset of Delve's service/api and
+// service/rpc2 types the lab uses. Field names and JSON tags must match
+// Delve's exactly; fields the lab never reads are left out.
+
+// Breakpoint is api.Breakpoint.
+type Breakpoint struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	File          string `json:"file"`
+	Line          int    `json:"line"`
+	FunctionName  string `json:"functionName,omitempty"`
+	Cond          string `json:"Cond"`
+	TotalHitCount uint64 `json:"totalHitCount"`
+}
+
+// Function is api.Function.
+type Function struct {
+	Name string `json:"name"`
+}
+
+// Thread is api.Thread.
+type Thread struct {
+	ID          int         `json:"id"`
+	File        string      `json:"file"`
+	Line        int         `json:"line"`
+	Function    *Function   `json:"function,omitempty"`
+	GoroutineID int64       `json:"goroutineID"`
+	Breakpoint  *Breakpoint `json:"breakPoint,omitempty"`
+}
+
+// State is api.DebuggerState.
+type State struct {
+	Running       bool    `json:"Running"`
+	CurrentThread *Thread `json:"currentThread,omitempty"`
+	Exited        bool    `json:"exited"`
+	ExitStatus    int     `json:"exitStatus"`
+}
+
+// Variable is api.Variable. Kind uses reflect's numbering, as Delve does.
+type Variable struct {
+	Name       string       `json:"name"`
+	Addr       uint64       `json:"addr"`
+	Type       string       `json:"type"`
+	RealType   string       `json:"realType"`
+	Kind       reflect.Kind `json:"kind"`
+	Value      string       `json:"value"`
+	Len        int64        `json:"len"`
+	Cap        int64        `json:"cap"`
+	Children   []Variable   `json:"children"`
+	Base       uint64       `json:"base"`
+	Unreadable string       `json:"unreadable"`
+}
+
+// LoadConfig is api.LoadConfig.
+type LoadConfig struct {
+	FollowPointers     bool
+	MaxVariableRecurse int
+	MaxStringLen       int
+	MaxArrayValues     int
+	MaxStructFields    int
+}
+
+// EvalScope is api.EvalScope. GoroutineID -1 means the selected
+// goroutine.
+type EvalScope struct {
+	GoroutineID  int64
+	Frame        int
+	DeferredCall int
+}
+
+type debuggerCommand struct {
+	Name string `json:"name"`
+}
+
+type createBreakpointIn struct {
+	Breakpoint Breakpoint
+}
+
+type createBreakpointOut struct {
+	Breakpoint Breakpoint
+}
+
+type commandOut struct {
+	State State
+}
+
+type evalIn struct {
+	Scope EvalScope
+	Expr  string
+	Cfg   *LoadConfig
+}
+
+type evalOut struct {
+	Variable *Variable
+}
+
+type detachIn struct {
+	Kill bool
+}
+
+type detachOut struct{}
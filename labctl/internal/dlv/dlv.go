@@ -0,0 +1,171 @@
+// Package dlv starts headless Delve servers and drives them over Delve's
+// JSON-RPC API (version 2), the same API the dlv terminal client uses.
+package dlv
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultLoadConfig loads enough of a value to compare it with what the
+// VS Code Variables panel shows (see .vscode/settings.json).
+var DefaultLoadConfig = LoadConfig{
+	FollowPointers:     true,
+	MaxVariableRecurse: 3,
+	MaxStringLen:       400,
+	MaxArrayValues:     64,
+	MaxStructFields:    -1,
+}
+
+// Server is a headless dlv process: `dlv exec --headless` or `dlv dap`.
+type Server struct {
+	Addr string // host:port of the JSON-RPC listener
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+const listeningPrefix = "API server listening at: "
+
+// Exec runs `dlv exec bin --headless` on a free loopback port with the
+// debuggee stopped at entry, and returns once the API server is
+// listening. Everything dlv and the debuggee print goes to out.
+func Exec(bin, dir string, args []string, out io.Writer) (*Server, error) {
+	dlvArgs := []string{"exec", bin, "--headless", "--api-version=2", "--listen=127.0.0.1:0"}
+	if len(args) > 0 {
+		dlvArgs = append(append(dlvArgs, "--"), args...)
+	}
+	cmd := exec.Command("dlv", dlvArgs...)
+	cmd.Dir = dir
+	return Listen(cmd, listeningPrefix, out)
+}
+
+// Listen starts cmd, a dlv command that prints prefix followed by the
+// address it listens on, and returns once it has. Everything else cmd
+// prints goes to out.
+func Listen(cmd *exec.Cmd, prefix string, out io.Writer) (*Server, error) {
+	name := strings.Join(cmd.Args[:min(2, len(cmd.Args))], " ")
+	cmd.Stderr = out
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	s := &Server{cmd: cmd, done: make(chan struct{})}
+	addr := make(chan string, 1)
+	go func() {
+		defer close(s.done)
+		sc := bufio.NewScanner(stdout)
+		for sc.Scan() {
+			line := sc.Text()
+			if a, ok := strings.CutPrefix(line, prefix); ok {
+				addr <- a
+				continue
+			}
+			fmt.Fprintln(out, line)
+		}
+		cmd.Wait()
+	}()
+
+	select {
+	case s.Addr = <-addr:
+		return s, nil
+	case <-s.done:
+		return nil, fmt.Errorf("%s exited before listening", name)
+	case <-time.After(30 * time.Second):
+		s.Close()
+		return nil, fmt.Errorf("timed out waiting for %s to listen", name)
+	}
+}
+
+// Close waits for dlv to exit, killing it if it is still running after
+// a few seconds.
+func (s *Server) Close() error {
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		s.cmd.Process.Kill()
+		<-s.done
+	}
+	return nil
+}
+
+// Client is a connection to a Delve JSON-RPC server.
+type Client struct {
+	rpc *rpc.Client
+}
+
+// Dial connects to a headless Delve server.
+func Dial(addr string) (*Client, error) {
+	conn, err := jsonrpc.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rpc: conn}, nil
+}
+
+func (c *Client) call(method string, in, out any) error {
+	return c.rpc.Call("RPCServer."+method, in, out)
+}
+
+// CreateBreakpoint sets a breakpoint on bp.File:bp.Line, optionally
+// with a condition, and returns it as Delve created it.
+func (c *Client) CreateBreakpoint(bp Breakpoint) (Breakpoint, error) {
+	var out createBreakpointOut
+	err := c.call("CreateBreakpoint", createBreakpointIn{Breakpoint: bp}, &out)
+	return out.Breakpoint, err
+}
+
+// Continue resumes the debuggee until it stops at a breakpoint or exits.
+func (c *Client) Continue() (State, error) {
+	return c.command("continue")
+}
+
+func (c *Client) command(name string) (State, error) {
+	var out commandOut
+	err := c.call("Command", debuggerCommand{Name: name}, &out)
+	if err != nil && strings.Contains(err.Error(), "has exited with status") {
+		return State{Exited: true}, nil
+	}
+	return out.State, err
+}
+
+// Eval evaluates expr in the topmost frame of the selected goroutine.
+func (c *Client) Eval(expr string) (Variable, error) {
+	return c.EvalIn(EvalScope{GoroutineID: -1}, expr)
+}
+
+// EvalIn evaluates expr in scope.
+func (c *Client) EvalIn(scope EvalScope, expr string) (Variable, error) {
+	var out evalOut
+	cfg := DefaultLoadConfig
+	if err := c.call("Eval", evalIn{Scope: scope, Expr: expr, Cfg: &cfg}, &out); err != nil {
+		return Variable{}, err
+	}
+	if out.Variable == nil {
+		return Variable{}, fmt.Errorf("eval %s: no result", expr)
+	}
+	return *out.Variable, nil
+}
+
+// Detach disconnects from the server; with kill it also ends the
+// debuggee, which makes a headless server exit.
+func (c *Client) Detach(kill bool) error {
+	var out detachOut
+	err := c.call("Detach", detachIn{Kill: kill}, &out)
+	c.rpc.Close()
+	// The server may hang up before answering.
+	if errors.Is(err, rpc.ErrShutdown) || errors.Is(err, io.ErrUnexpectedEOF) {
+		err = nil
+	}
+	return err
+}
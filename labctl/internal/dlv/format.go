@@ -0,0 +1,67 @@
+package dlv
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// String renders v the way fmt's %v verb prints the same Go value, so
+// expectations can be written exactly like the program's own output:
+// 10, [1 999 3], {10 c1}, map[key:999], <nil>.
+func (v Variable) String() string {
+	if v.Unreadable != "" {
+		return "<unreadable: " + v.Unreadable + ">"
+	}
+	switch v.Kind {
+	case reflect.Slice, reflect.Array:
+		if v.Kind == reflect.Slice && v.Base == 0 && v.Len == 0 {
+			return "[]"
+		}
+		return "[" + joinChildren(v.Children, v.Len) + "]"
+	case reflect.Struct:
+		return "{" + joinChildren(v.Children, int64(len(v.Children))) + "}"
+	case reflect.Map:
+		pairs := make([]string, 0, len(v.Children)/2)
+		for i := 0; i+1 < len(v.Children); i += 2 {
+			pairs = append(pairs, v.Children[i].String()+":"+v.Children[i+1].String())
+		}
+		sort.Strings(pairs)
+		return "map[" + strings.Join(pairs, " ") + "]"
+	case reflect.Pointer:
+		if len(v.Children) == 0 || v.Children[0].Addr == 0 {
+			return "<nil>"
+		}
+		switch pointee := v.Children[0]; pointee.Kind {
+		case reflect.Struct, reflect.Array, reflect.Slice, reflect.Map:
+			return "&" + pointee.String()
+		default:
+			return fmt.Sprintf("%#x", pointee.Addr)
+		}
+	case reflect.Interface:
+		if len(v.Children) == 0 || v.Children[0].Kind == reflect.Invalid {
+			return "<nil>"
+		}
+		return v.Children[0].String()
+	case reflect.Chan, reflect.UnsafePointer:
+		if v.Base == 0 && v.Addr == 0 {
+			return "<nil>"
+		}
+		return fmt.Sprintf("%#x", v.Base)
+	}
+	return v.Value
+}
+
+// joinChildren formats loaded children, marking elements Delve did not
+// load because of MaxArrayValues.
+func joinChildren(children []Variable, n int64) string {
+	parts := make([]string, 0, len(children)+1)
+	for _, c := range children {
+		parts = append(parts, c.String())
+	}
+	if n > int64(len(children)) {
+		parts = append(parts, "...")
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,247 @@
+// Package escape asks the compiler where a module's variables live. It
+// builds the module with -gcflags=-m=2, which makes escape analysis
+// explain itself, and attaches each decision to the 🤔 marker of the
+// function that declares the variable:
+//
+//	./main.go:25:2: local escapes to heap in createPointer:
+//	./main.go:25:2:   flow: ~r0 ← &local:
+//	./main.go:25:2:     from &local (address-of) at ./main.go:30:9
+//	./main.go:25:2:     from return &local (return) at ./main.go:30:2
+//	./main.go:25:2: moved to heap: local
+//
+// A variable the compiler says nothing about stays on the stack.
+package escape
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/manifest"
+	"debugger-lab/labctl/internal/markers"
+)
+
+// Pos is a position in a module source, as the compiler prints it but
+// relative to the module directory: "main.go:25:2".
+type Pos string
+
+// Decision is what escape analysis decided for one variable.
+type Decision struct {
+	Heap bool // "moved to heap"
+	// Flow is the compiler's explanation, one line each, from "local
+	// escapes to heap in createPointer:" down to the last "from".
+	Flow []string
+}
+
+// Report holds the decisions of a build, by the position where each
+// variable is declared.
+type Report map[Pos]*Decision
+
+var (
+	lineRe    = regexp.MustCompile(`^(\S+?\.go:\d+:\d+): (.*)$`)
+	movedRe   = regexp.MustCompile(`^moved to heap: \w+$`)
+	escapesRe = regexp.MustCompile(`^\S+ escapes to heap in \S+:$`)
+	// A relative position inside a diagnostic, as in "at ./main.go:30:9".
+	posRe = regexp.MustCompile(`(^|[\s(])\./`)
+)
+
+// Parse reads the output of go build -gcflags=-m=2 run in a module
+// directory.
+func Parse(r io.Reader) (Report, error) {
+	rep := Report{}
+	var flow *[]string // the explanation being read, if any
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		m := lineRe.FindStringSubmatch(sc.Text())
+		if m == nil {
+			flow = nil
+			continue
+		}
+		pos := Pos(path.Clean(m[1]))
+		msg := posRe.ReplaceAllString(m[2], "$1")
+		d := rep[pos]
+		switch {
+		case strings.HasPrefix(msg, " ") && flow != nil:
+			*flow = append(*flow, msg)
+		case escapesRe.MatchString(msg):
+			// Only the first explanation at a position is kept: the
+			// compiler repeats it for every use of the variable.
+			if d == nil {
+				d = &Decision{}
+				rep[pos] = d
+			}
+			flow = nil
+			if len(d.Flow) == 0 {
+				d.Flow = append(d.Flow, msg)
+				flow = &d.Flow
+			}
+		case movedRe.MatchString(msg):
+			if d == nil {
+				d = &Decision{}
+				rep[pos] = d
+			}
+			d.Heap = true
+			flow = nil
+		default:
+			flow = nil
+		}
+	}
+	return rep, sc.Err()
+}
+
+// Build compiles every package of m with -gcflags=-m=2, discarding the
+// binaries, and parses what the compiler reports.
+func Build(m lab.Module) (Report, error) {
+	cmd := exec.Command("go", "build", "-gcflags=-m=2", "-o", os.DevNull, "./...")
+	cmd.Dir = m.Dir
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("building %s: %v\n%s", m.Name, err, out.Bytes())
+	}
+	return Parse(&out)
+}
+
+// Var is a variable declared in a function, with the compiler's
+// decision about it.
+type Var struct {
+	Name string
+	Pos  Pos
+	Decision
+}
+
+// Where returns "heap" or "stack".
+func (v Var) Where() string {
+	if v.Heap {
+		return "heap"
+	}
+	return "stack"
+}
+
+// Vars lists the parameters and local variables of the function mk is
+// in, or documents, in declaration order, with rep's decision for each.
+// Function literals inside it are part of it.
+func Vars(m lab.Module, mk markers.Marker, rep Report) ([]Var, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join(m.Dir, filepath.FromSlash(mk.File)), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	var fn *ast.FuncDecl
+	for _, d := range f.Decls {
+		if d, ok := d.(*ast.FuncDecl); ok && d.Body != nil &&
+			fset.Position(d.Pos()).Line <= mk.StmtLine && mk.StmtLine <= fset.Position(d.End()).Line {
+			fn = d
+		}
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("%s:%d: marker is not in a function", mk.File, mk.Line)
+	}
+
+	var vars []Var
+	add := func(id *ast.Ident) {
+		if id.Name == "_" {
+			return
+		}
+		p := fset.Position(id.Pos())
+		pos := Pos(fmt.Sprintf("%s:%d:%d", mk.File, p.Line, p.Column))
+		v := Var{Name: id.Name, Pos: pos}
+		if d := rep[pos]; d != nil {
+			v.Decision = *d
+		}
+		vars = append(vars, v)
+	}
+	fields := func(fl *ast.FieldList) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			for _, id := range field.Names {
+				add(id)
+			}
+		}
+	}
+	fields(fn.Recv)
+	fields(fn.Type.Params)
+	fields(fn.Type.Results)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				for _, e := range n.Lhs {
+					if id, ok := e.(*ast.Ident); ok {
+						add(id)
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.DEFINE {
+				for _, e := range []ast.Expr{n.Key, n.Value} {
+					if id, ok := e.(*ast.Ident); ok {
+						add(id)
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, id := range n.Names {
+				add(id)
+			}
+		case *ast.FuncLit:
+			fields(n.Type.Params)
+			fields(n.Type.Results)
+		}
+		return true
+	})
+	return vars, nil
+}
+
+// Result is one escape claim of a manifest, checked.
+type Result struct {
+	Claim  manifest.Escape
+	Marker markers.Marker
+	Var    Var // zero if the function declares no such variable
+	Err    error
+}
+
+// Failed reports whether the claim does not hold.
+func (r Result) Failed() bool {
+	return r.Err != nil || r.Var.Heap != r.Claim.Heap
+}
+
+// Check compares each escape claim of man with rep.
+func Check(m lab.Module, man *manifest.Manifest, found []markers.Marker, rep Report) []Result {
+	var results []Result
+	for _, c := range man.Escapes {
+		r := Result{Claim: c}
+		r.Marker, r.Err = c.Resolve(found)
+		if r.Err == nil {
+			r.Var, r.Err = lookup(m, r.Marker, rep, c.Var)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func lookup(m lab.Module, mk markers.Marker, rep Report, name string) (Var, error) {
+	vars, err := Vars(m, mk, rep)
+	if err != nil {
+		return Var{}, err
+	}
+	for _, v := range vars {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+	return Var{}, fmt.Errorf("%s declares no variable %s", mk.Func, name)
+}
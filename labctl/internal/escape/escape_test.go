@@ -0,0 +1,89 @@
+package escape
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/manifest"
+	"debugger-lab/labctl/internal/markers"
+)
+
+const sample = `# debugger-lab/04-pointers-and-memory
+./main.go:27:6: can inline createValue
+./main.go:28:2: local escapes to heap in createPointer:
+./main.go:28:2:   flow: ~r0 ← &local:
+./main.go:28:2:     from &local (address-of) at ./main.go:35:9
+./main.go:28:2:     from return &local (return) at ./main.go:35:2
+./main.go:28:2: local escapes to heap in createPointer:
+./main.go:28:2:   flow: {heap} ← &local:
+./main.go:28:2: moved to heap: local
+./main.go:31:13: ... argument does not escape
+current/loops.go:17:6: moved to heap: i
+`
+
+func TestParse(t *testing.T) {
+	rep, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Report{
+		"main.go:28:2": {
+			Heap: true,
+			Flow: []string{
+				"local escapes to heap in createPointer:",
+				"  flow: ~r0 ← &local:",
+				"    from &local (address-of) at main.go:35:9",
+				"    from return &local (return) at main.go:35:2",
+			},
+		},
+		"current/loops.go:17:6": {Heap: true},
+	}
+	if !reflect.DeepEqual(rep, want) {
+		t.Errorf("Parse:\n got %v\nwant %v", rep, want)
+	}
+}
+
+// TestClaims builds every module that makes escape claims and checks
+// them against the compiler.
+func TestClaims(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds modules")
+	}
+	root, err := lab.FindRoot(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mods, err := lab.Modules(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range mods {
+		man, err := manifest.Load(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(man.Escapes) == 0 {
+			continue
+		}
+		t.Run(m.Name, func(t *testing.T) {
+			found, err := markers.ParseDir(m.Dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rep, err := Build(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range Check(m, man, found, rep) {
+				switch {
+				case r.Err != nil:
+					t.Errorf("%s: %v", r.Claim, r.Err)
+				case r.Failed():
+					t.Errorf("%s: compiler puts %s on the %s\n%s", r.Claim, r.Var.Name, r.Var.Where(), strings.Join(r.Var.Flow, "\n"))
+				}
+			}
+		})
+	}
+}
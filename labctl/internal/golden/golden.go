@@ -0,0 +1,180 @@
+// Package golden runs a module's program and compares what it prints
+// with testdata/main.golden in the module, and each of the runs its
+// lab.json lists with testdata/<run>.golden.
+//
+// Output that changes from run to run is masked before the comparison,
+// by rules listed in the module's lab.json under "mask". Rules apply in
+// order, line by line:
+//
+//	addr                      replace 0x… pointers (%p) with <addr>
+//	replace <regexp> => <s>   replace matches like regexp.ReplaceAllString
+//	drop <regexp>             remove matching lines
+//	sort <regexp>             sort each run of consecutive matching lines,
+//	                          for goroutines whose order is not fixed
+//
+// For example, module 09:
+//
+//	"mask": [
+//	  "replace ^(Final counter:) \\d+$ => $1 <n>",
+//	  "sort ^Worker \\d (starting|finished)$|^Main: goroutines launched$"
+//	]
+package golden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"debugger-lab/labctl/internal/lab"
+)
+
+// File is the golden file of a module, relative to its directory.
+const File = "testdata/main.golden"
+
+// Main is the name of the plain run of a program, without the lab.json
+// runs' arguments and environment.
+const Main = "main"
+
+// RunFile is the golden file of the named run of a module, relative to
+// its directory; RunFile(Main) is File.
+func RunFile(run string) string {
+	return "testdata/" + run + ".golden"
+}
+
+// rule is one parsed mask rule.
+type rule struct {
+	op   string // "replace", "drop" or "sort"
+	re   *regexp.Regexp
+	repl string
+}
+
+// Mask is a parsed list of mask rules.
+type Mask []rule
+
+var addrRe = regexp.MustCompile(`0x[0-9a-f]{6,}`)
+
+// ParseMask parses mask rules.
+func ParseMask(rules []string) (Mask, error) {
+	var mask Mask
+	for _, r := range rules {
+		op, arg, _ := strings.Cut(r, " ")
+		var err error
+		switch op {
+		case "addr":
+			if arg != "" {
+				return nil, fmt.Errorf("mask %q: addr takes no argument", r)
+			}
+			mask = append(mask, rule{op: "replace", re: addrRe, repl: "<addr>"})
+		case "replace":
+			pattern, repl, ok := strings.Cut(arg, " => ")
+			if !ok {
+				return nil, fmt.Errorf("mask %q: want replace <regexp> => <replacement>", r)
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("mask %q: %w", r, err)
+			}
+			mask = append(mask, rule{op: op, re: re, repl: repl})
+		case "drop", "sort":
+			var re *regexp.Regexp
+			if re, err = regexp.Compile(arg); err != nil {
+				return nil, fmt.Errorf("mask %q: %w", r, err)
+			}
+			mask = append(mask, rule{op: op, re: re})
+		default:
+			return nil, fmt.Errorf("mask %q: unknown rule %q", r, op)
+		}
+	}
+	return mask, nil
+}
+
+// Apply returns out with every rule applied.
+func (mask Mask) Apply(out []byte) []byte {
+	if len(mask) == 0 {
+		return out
+	}
+	text := string(out)
+	trailingNewline := strings.HasSuffix(text, "\n")
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for _, r := range mask {
+		switch r.op {
+		case "replace":
+			for i, l := range lines {
+				lines[i] = r.re.ReplaceAllString(l, r.repl)
+			}
+		case "drop":
+			lines = slices.DeleteFunc(lines, r.re.MatchString)
+		case "sort":
+			for i := 0; i < len(lines); {
+				j := i
+				for j < len(lines) && r.re.MatchString(lines[j]) {
+					j++
+				}
+				slices.Sort(lines[i:j])
+				i = max(j, i+1)
+			}
+		}
+	}
+	text = strings.Join(lines, "\n")
+	if trailingNewline {
+		text += "\n"
+	}
+	return []byte(text)
+}
+
+// timeout bounds a program run; the lab programs finish in well under a
+// second, apart from their sleeps.
+const timeout = 30 * time.Second
+
+// Run builds m and returns what it prints on stdout when run with args
+// from the module directory, with env (KEY=value pairs) added to its
+// environment. It fails unless the program exits with status exit;
+// what the program printed on stderr is then part of the error.
+func Run(m lab.Module, args, env []string, exit int) ([]byte, error) {
+	if !m.IsMain() {
+		return nil, fmt.Errorf("%s has no main package", m.Name)
+	}
+	tmp, err := os.MkdirTemp("", "labctl-golden-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	bin := filepath.Join(tmp, m.Name)
+
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = m.Dir
+	if out, err := build.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("building %s: %v\n%s", m.Name, err, out)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = m.Dir
+	cmd.Env = append(os.Environ(), env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		err = fmt.Errorf("timed out after %v", timeout)
+	case err == nil && exit != 0:
+		err = fmt.Errorf("exit status 0, want %d", exit)
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == exit:
+		err = nil
+	}
+	if err != nil {
+		return stdout.Bytes(), fmt.Errorf("running %s: %v\n%s", m.Name, err, stderr.Bytes())
+	}
+	return stdout.Bytes(), nil
+}
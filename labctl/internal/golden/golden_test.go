@@ -0,0 +1,126 @@
+package golden_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"debugger-lab/labctl/internal/golden"
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/manifest"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files from the programs' current output")
+
+// TestGolden runs every module's program, plainly and as each run in
+// its lab.json, and compares the masked output with the golden files.
+func TestGolden(t *testing.T) {
+	root, err := lab.FindRoot(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mods, err := lab.Modules(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range mods {
+		if !m.IsMain() {
+			continue
+		}
+		t.Run(m.Name, func(t *testing.T) {
+			t.Parallel()
+			man, err := manifest.Load(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mask, err := golden.ParseMask(man.Mask)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, m, mask, golden.File, man.Args, nil, 0)
+			for _, r := range man.Runs {
+				t.Run(r.Name, func(t *testing.T) {
+					checkGolden(t, m, mask, golden.RunFile(r.Name), r.Args, r.Environ(), r.Exit)
+				})
+			}
+		})
+	}
+}
+
+// checkGolden runs m with args and env, expecting exit status exit, and
+// compares its masked output with the golden file, or rewrites the file
+// with -update.
+func checkGolden(t *testing.T, m lab.Module, mask golden.Mask, file string, args, env []string, exit int) {
+	t.Helper()
+	out, err := golden.Run(m, args, env, exit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := mask.Apply(out)
+
+	path := filepath.Join(m.Dir, filepath.FromSlash(file))
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test ./internal/golden/ -update)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s/%s:\n%s", m.Name, file, lineDiff(want, got))
+	}
+}
+
+// lineDiff lists the lines that differ, by line number.
+func lineDiff(want, got []byte) string {
+	w := bytes.Split(want, []byte("\n"))
+	g := bytes.Split(got, []byte("\n"))
+	var buf bytes.Buffer
+	for i := range max(len(w), len(g)) {
+		var wl, gl []byte
+		if i < len(w) {
+			wl = w[i]
+		}
+		if i < len(g) {
+			gl = g[i]
+		}
+		if !bytes.Equal(wl, gl) {
+			fmt.Fprintf(&buf, "line %d:\n  want: %s\n  got:  %s\n", i+1, wl, gl)
+		}
+	}
+	return buf.String()
+}
+
+func TestMask(t *testing.T) {
+	mask, err := golden.ParseMask([]string{
+		"addr",
+		`replace ^(Racy counter:) \d+ => $1 <n>`,
+		"drop ^Value: ",
+		`sort ^Goroutine \d working$`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := "x at 0xc000012345\nRacy counter: 9874 (expected 10000)\nValue: 42\n" +
+		"Goroutine 2 working\nGoroutine 0 working\ndone\nGoroutine 1 working\n"
+	want := "x at <addr>\nRacy counter: <n> (expected 10000)\n" +
+		"Goroutine 0 working\nGoroutine 2 working\ndone\nGoroutine 1 working\n"
+	if got := string(mask.Apply([]byte(in))); got != want {
+		t.Errorf("Apply:\n%s\nwant:\n%s", got, want)
+	}
+
+	for _, bad := range []string{"addr 1", "replace x", "sort (", "shuffle x"} {
+		if _, err := golden.ParseMask([]string{bad}); err == nil {
+			t.Errorf("ParseMask(%q) succeeded", bad)
+		}
+	}
+}
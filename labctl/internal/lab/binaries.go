@@ -0,0 +1,88 @@
+package lab
+
+import (
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// StrayBinaries lists compiled executables left inside the module:
+// Delve's __debug_bin* files and the output of a plain `go build`.
+// Other binaries are recognized by their header, not their name, so
+// renamed builds such as test_build are found too.
+func StrayBinaries(m Module) ([]string, error) {
+	var found []string
+	err := filepath.WalkDir(m.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != m.Dir && d.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		// A __debug_bin from an interrupted session may be truncated or
+		// garbled, so trust the name.
+		if strings.HasPrefix(d.Name(), "__debug_bin") {
+			found = append(found, path)
+			return nil
+		}
+		ok, err := isBinary(path)
+		if err != nil {
+			return err
+		}
+		if ok {
+			found = append(found, path)
+		}
+		return nil
+	})
+	return found, err
+}
+
+// isBinary reports whether path is an executable for one of the
+// platforms students build on: ELF (Linux), Mach-O, thin or universal
+// (macOS), or PE (Windows). The whole header must parse, so that files
+// which merely start with the same bytes, such as Java class files and
+// universal Mach-O binaries, both 0xcafebabe, are told apart. Shared
+// libraries, such as a prebuilt cgo library kept in a module, are not
+// executables: an ELF shared object counts only with a PT_INTERP
+// program header, which Go's PIE executables have.
+func isBinary(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	if e, err := elf.NewFile(f); err == nil {
+		switch e.Type {
+		case elf.ET_EXEC:
+			return true, nil
+		case elf.ET_DYN:
+			for _, p := range e.Progs {
+				if p.Type == elf.PT_INTERP {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+	if m, err := macho.NewFile(f); err == nil {
+		return m.Type == macho.TypeExec, nil
+	}
+	if fat, err := macho.NewFatFile(f); err == nil {
+		return fat.Arches[0].Type == macho.TypeExec, nil
+	}
+	if p, err := pe.NewFile(f); err == nil {
+		c := p.Characteristics
+		return c&pe.IMAGE_FILE_EXECUTABLE_IMAGE != 0 && c&pe.IMAGE_FILE_DLL == 0, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,75 @@
+package lab
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// elfFile returns a minimal 64-bit little-endian ELF file of type typ
+// with a single program header of type prog.
+func elfFile(typ elf.Type, prog elf.ProgType) []byte {
+	var buf bytes.Buffer
+	h := elf.Header64{
+		Type:      uint16(typ),
+		Machine:   uint16(elf.EM_X86_64),
+		Version:   uint32(elf.EV_CURRENT),
+		Phoff:     64,
+		Ehsize:    64,
+		Phentsize: 56,
+		Phnum:     1,
+	}
+	copy(h.Ident[:], elf.ELFMAG)
+	h.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	h.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	h.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	binary.Write(&buf, binary.LittleEndian, h)
+	binary.Write(&buf, binary.LittleEndian, elf.Prog64{Type: uint32(prog)})
+	return buf.Bytes()
+}
+
+func TestStrayBinaries(t *testing.T) {
+	files := []struct {
+		name  string
+		data  []byte
+		stray bool
+	}{
+		{"exec", elfFile(elf.ET_EXEC, elf.PT_LOAD), true},
+		{"pie", elfFile(elf.ET_DYN, elf.PT_INTERP), true},
+		{"libgreet.so", elfFile(elf.ET_DYN, elf.PT_LOAD), false},
+		{"object.o", elfFile(elf.ET_REL, elf.PT_NULL), false},
+		{"Main.class", []byte{0xca, 0xfe, 0xba, 0xbe, 0x00, 0x00, 0x00, 0x41, 0x00, 0x1d, 0x0a}, false},
+		{"notes.txt", []byte("MZ is where it starts\n"), false},
+		{"__debug_bin1234", []byte{0x7f, 'E'}, true},
+		{"main.go", []byte("package main\n"), false},
+		{"testdata/exec", elfFile(elf.ET_EXEC, elf.PT_LOAD), false},
+	}
+	dir := t.TempDir()
+	var want []string
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f.name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, f.data, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if f.stray {
+			want = append(want, path)
+		}
+	}
+
+	got, err := StrayBinaries(Module{Name: "00-test", Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("StrayBinaries = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,166 @@
+// Package lab discovers the numbered lab modules that make up the
+// repository (01-main-and-entrypoint … 17-iterators).
+package lab
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DebugGCFlags disables optimizations and inlining, exactly like the
+// buildFlags in .vscode/launch.json.
+const DebugGCFlags = "all=-N -l"
+
+// Module is one numbered lab directory with its own go.mod.
+type Module struct {
+	Number  int    // 1 for 01-main-and-entrypoint
+	Name    string // directory name, e.g. "01-main-and-entrypoint"
+	Dir     string // absolute path to the module directory
+	Title   string // README heading without the "Module NN:" prefix
+	Package string // package name of the non-test sources, e.g. "main"
+}
+
+// Slug returns the directory name without its number prefix.
+func (m Module) Slug() string {
+	return m.Name[3:]
+}
+
+// IsMain reports whether the module builds a program. Test-only modules
+// like 13-debugging-tests (package calculator) can only be run via go test.
+func (m Module) IsMain() bool {
+	return m.Package == "main"
+}
+
+// HasTests reports whether the module has _test.go files.
+func (m Module) HasTests() bool {
+	files, _ := filepath.Glob(filepath.Join(m.Dir, "*_test.go"))
+	return len(files) > 0
+}
+
+// DebugPort is the port a headless Delve for this module listens on, so
+// that the "Attach to Module NN" launch configuration can find it: 2300
+// plus the module number.
+func (m Module) DebugPort() int {
+	return 2300 + m.Number
+}
+
+var moduleDirRe = regexp.MustCompile(`^(\d{2})-[a-z0-9-]+$`)
+
+// FindRoot walks up from dir until it finds the go.work that ties the
+// lab modules together.
+func FindRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.work")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.work not found: run labctl inside the debugger lab")
+		}
+		dir = parent
+	}
+}
+
+// Modules returns every lab module under root, ordered by number.
+func Modules(root string) ([]Module, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	var mods []Module
+	for _, e := range entries {
+		match := moduleDirRe.FindStringSubmatch(e.Name())
+		if !e.IsDir() || match == nil {
+			continue
+		}
+		dir := filepath.Join(root, e.Name())
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+			continue
+		}
+		n, _ := strconv.Atoi(match[1])
+		pkg, err := packageName(dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		mods = append(mods, Module{
+			Number:  n,
+			Name:    e.Name(),
+			Dir:     dir,
+			Title:   readmeTitle(dir),
+			Package: pkg,
+		})
+	}
+	sort.Slice(mods, func(i, j int) bool { return mods[i].Number < mods[j].Number })
+	return mods, nil
+}
+
+// Lookup finds a module by number ("1", "01"), full directory name
+// ("01-main-and-entrypoint") or slug ("main-and-entrypoint").
+func Lookup(mods []Module, query string) (Module, error) {
+	query = strings.TrimSuffix(query, "/")
+	for _, m := range mods {
+		if query == m.Name || query == m.Slug() {
+			return m, nil
+		}
+		if n, err := strconv.Atoi(query); err == nil && n == m.Number {
+			return m, nil
+		}
+	}
+	return Module{}, fmt.Errorf("unknown module %q (see labctl list)", query)
+}
+
+// packageName returns the package clause of the first non-test Go file
+// in dir.
+func packageName(dir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), f, nil, parser.PackageClauseOnly)
+		if err != nil {
+			return "", err
+		}
+		return file.Name.Name, nil
+	}
+	return "", errors.New("no Go source files")
+}
+
+// readmeTitle extracts "Main and Entrypoint" from the
+// "# Module 01: Main and Entrypoint" heading of the module README.
+func readmeTitle(dir string) string {
+	f, err := os.Open(filepath.Join(dir, "README.md"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		title := strings.TrimPrefix(line, "# ")
+		if _, rest, ok := strings.Cut(title, ": "); ok {
+			title = rest
+		}
+		return title
+	}
+	return ""
+}
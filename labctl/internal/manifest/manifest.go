@@ -0,0 +1,446 @@
+// Package manifest loads and validates lab.json, the machine-readable
+// description of a module: what it teaches, where its walkthrough
+// stops, the values a student should see there and the questions it
+// asks.
+//
+// Stops are anchored to marker comments by function, marker text and,
+// where a function has several identical markers, the statement the
+// marker points at — never by line number, so editing a module does not
+// silently break the tooling built on it:
+//
+//	{
+//	  "title": "Variables and Scope",
+//	  "focus": "Same name does not mean same variable.",
+//	  "breakpoints": [
+//	    {"func": "main", "stmt": "x := 20", "note": "a second x appears"}
+//	  ],
+//	  "observations": [
+//	    {"func": "main", "marker": "x is still 10", "expect": {"x": "10"}}
+//	  ],
+//	  "questions": [
+//	    {"id": "shadowing", "prompt": "Do shadowed variables share memory?",
+//	     "choices": ["yes", "no"], "answers": ["no"]},
+//	    {"id": "captured", "prompt": "What do the `Captured i:` lines print?",
+//	     "output": "^Captured i: (\\d+)$"}
+//	  ]
+//	}
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"debugger-lab/labctl/internal/golden"
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/markers"
+)
+
+// File is the name of the per-module manifest.
+const File = "lab.json"
+
+// Manifest is the content of a module's lab.json.
+type Manifest struct {
+	Title string `json:"title"` // must match the README's "# Module NN: Title"
+	Focus string `json:"focus"` // one or two sentences on what the module teaches
+	// Args are passed to the program, or to the test binary for
+	// test-only modules (e.g. ["-test.run", "^TestAdd$"]), whenever
+	// tooling runs the module.
+	Args []string `json:"args,omitempty"`
+	// Mask lists the rules that hide run-to-run differences in the
+	// program's output before it is compared with its golden file (see
+	// package golden).
+	Mask []string `json:"mask,omitempty"`
+	// Runs are further invocations of the program worth debugging, each
+	// with its own launch configuration and golden file.
+	Runs         []Run         `json:"runs,omitempty"`
+	Breakpoints  []Breakpoint  `json:"breakpoints"`
+	Observations []Observation `json:"observations,omitempty"`
+	Escapes      []Escape      `json:"escapes,omitempty"`
+	Questions    []Question    `json:"questions,omitempty"`
+}
+
+// Run is a named invocation of a module's program, such as module 14's
+//
+//	{"name": "exit", "args": ["-scenario", "exit"], "exit": 3}
+//
+// which `labctl launch` turns into the configuration "Debug Module 14
+// (exit-paths): exit" and the golden test checks against
+// testdata/exit.golden.
+type Run struct {
+	Name string            `json:"name"`
+	Args []string          `json:"args,omitempty"`
+	Env  map[string]string `json:"env,omitempty"` // added to the environment
+	// Exit is the status the run ends with, for programs that crash on
+	// purpose, such as module 14's os.Exit(3).
+	Exit int `json:"exit,omitempty"`
+}
+
+// Environ returns Env as sorted KEY=value pairs.
+func (r Run) Environ() []string {
+	var env []string
+	for k, v := range r.Env {
+		env = append(env, k+"="+v)
+	}
+	slices.Sort(env)
+	return env
+}
+
+var runNameRe = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Anchor names a marker comment without using its line number.
+type Anchor struct {
+	// File is the marker's file relative to the module, needed only
+	// when functions in different packages share a name, such as
+	// legacy/loops.go and current/loops.go.
+	File string `json:"file,omitempty"`
+	Func string `json:"func"` // function as markers names it, e.g. "TestAdd.func1"
+	// Marker is text the marker comment contains. It may be empty for a
+	// breakpoint anchor when Stmt identifies the marker.
+	Marker string `json:"marker,omitempty"`
+	// Stmt is text of the statement the marker resolves to, needed
+	// only to tell apart markers that read the same.
+	Stmt string `json:"stmt,omitempty"`
+	// Nth picks, counting from 1 in source order, among markers that
+	// still match, such as two loops that read the same.
+	Nth int `json:"nth,omitempty"`
+}
+
+func (a Anchor) String() string {
+	s := a.Func
+	if a.File != "" {
+		s = a.File + ": " + s
+	}
+	if a.Marker != "" {
+		s += fmt.Sprintf(" %q", a.Marker)
+	}
+	if a.Stmt != "" {
+		s += fmt.Sprintf(" at `%s`", a.Stmt)
+	}
+	if a.Nth > 0 {
+		s += fmt.Sprintf(" #%d", a.Nth)
+	}
+	return s
+}
+
+// Resolve finds the single marker in found that a names.
+func (a Anchor) Resolve(found []markers.Marker) (markers.Marker, error) {
+	var match []markers.Marker
+	for _, mk := range found {
+		if a.File != "" && mk.File != a.File {
+			continue
+		}
+		if mk.Func != a.Func || !strings.Contains(mk.Text, a.Marker) || !strings.Contains(mk.Stmt, a.Stmt) {
+			continue
+		}
+		// A doc comment marker and one inside the body can point at
+		// the same statement; that is one stop, not two.
+		if n := len(match); n > 0 && match[n-1].Pos() == mk.Pos() {
+			continue
+		}
+		match = append(match, mk)
+	}
+	switch {
+	case a.Nth > 0 && a.Nth <= len(match):
+		return match[a.Nth-1], nil
+	case len(match) == 0 || a.Nth > 0:
+		return markers.Marker{}, fmt.Errorf("no marker %s", a)
+	case len(match) == 1:
+		return match[0], nil
+	}
+	var at []string
+	for _, mk := range match {
+		at = append(at, fmt.Sprintf("%s:%d", mk.File, mk.Line))
+	}
+	return markers.Marker{}, fmt.Errorf("marker %s is ambiguous: %s", a, strings.Join(at, ", "))
+}
+
+// Breakpoint is a stop in the walkthrough. It must anchor a 🔍 SET
+// BREAKPOINT HERE marker.
+type Breakpoint struct {
+	Anchor
+	Note string `json:"note,omitempty"` // what to look at when it is hit
+}
+
+// Resolve finds the 🔍 SET BREAKPOINT HERE marker bp names; other
+// markers on the same statement do not make it ambiguous.
+func (bp Breakpoint) Resolve(found []markers.Marker) (markers.Marker, error) {
+	found = slices.DeleteFunc(slices.Clone(found), func(mk markers.Marker) bool {
+		return mk.Kind != markers.Breakpoint
+	})
+	return bp.Anchor.Resolve(found)
+}
+
+// Observation is a stop where specific values must be seen.
+type Observation struct {
+	Anchor
+	// Hit selects which time the breakpoint is hit; 0 and 1 both mean
+	// the first.
+	Hit int `json:"hit,omitempty"`
+	// Expect maps expressions to their value as fmt's %v prints them.
+	Expect map[string]string `json:"expect"`
+}
+
+func (o Observation) String() string {
+	s := o.Anchor.String()
+	if o.Hit > 1 {
+		s += fmt.Sprintf(" (hit %d)", o.Hit)
+	}
+	return s
+}
+
+// Escape is what escape analysis decides about a variable of the
+// function at a 🤔 marker, for `labctl escape` to check against the
+// compiler and to state in the module README:
+//
+//	{"func": "createValue", "marker": "DOES THIS ESCAPE", "var": "local", "heap": false,
+//	 "why": "only its value leaves the function"}
+type Escape struct {
+	Anchor
+	Var  string `json:"var"`
+	Heap bool   `json:"heap"` // false: the variable stays on the stack
+	Why  string `json:"why"`  // the reason the README gives
+}
+
+func (e Escape) String() string {
+	where := "stack"
+	if e.Heap {
+		where = "heap"
+	}
+	return fmt.Sprintf("%s: %s on the %s", e.Anchor, e.Var, where)
+}
+
+// The section of a module README between these lines states the
+// manifest's escapes; it is owned by `labctl escape -readme`.
+const (
+	BeginEscapes = "<!-- BEGIN ESCAPES: generated by `go run ./labctl escape -readme`; DO NOT EDIT -->"
+	EndEscapes   = "<!-- END ESCAPES -->"
+)
+
+// EscapeText is the README's escapes section for escapes: a bullet per
+// claim, which reads "`createValue`'s `local` stays on the **stack**:"
+// followed by its reason.
+func EscapeText(escapes []Escape) []byte {
+	var buf bytes.Buffer
+	for _, e := range escapes {
+		where := "stays on the **stack**"
+		if e.Heap {
+			where = "is on the **heap**"
+		}
+		fmt.Fprintf(&buf, "- `%s`'s `%s` %s: %s\n", e.Func, e.Var, where, e.Why)
+	}
+	return buf.Bytes()
+}
+
+// escapeSection returns the text of the README between BeginEscapes
+// and EndEscapes.
+func escapeSection(readme []byte) ([]byte, bool) {
+	_, rest, ok := bytes.Cut(readme, []byte(BeginEscapes+"\n"))
+	if !ok {
+		return nil, false
+	}
+	text, _, ok := bytes.Cut(rest, []byte(EndEscapes))
+	return text, ok
+}
+
+// Question is one of the README's "Questions to Answer". With Choices
+// it is multiple choice and every accepted answer must be one of them;
+// without, any answer equal to an accepted one after Normalize is right.
+//
+// A question with Output asks the student to predict what the program
+// prints. It has no Answers: the answer is whatever a run of the module
+// prints, as Printed extracts it.
+type Question struct {
+	ID      string   `json:"id"`
+	Prompt  string   `json:"prompt"`
+	Choices []string `json:"choices,omitempty"`
+	Answers []string `json:"answers,omitempty"`
+	// Output is a regexp selecting lines of the program's masked
+	// output; its first group, or the whole match without one, is
+	// taken from each.
+	Output  string `json:"output,omitempty"`
+	Explain string `json:"explain,omitempty"` // shown after answering
+}
+
+// Accepts reports whether answer is one of q's accepted answers.
+func (q Question) Accepts(answer string) bool {
+	for _, a := range q.Answers {
+		if Normalize(a) == Normalize(answer) {
+			return true
+		}
+	}
+	return false
+}
+
+// Printed returns what the program printed for an Output question: the
+// text Output selects from each matching line of out, joined by spaces.
+func (q Question) Printed(out []byte) (string, error) {
+	re, err := regexp.Compile(q.Output)
+	if err != nil {
+		return "", fmt.Errorf("question %q: %w", q.ID, err)
+	}
+	var parts []string
+	for _, line := range strings.Split(string(out), "\n") {
+		m := re.FindStringSubmatch(line)
+		switch {
+		case m == nil:
+		case len(m) > 1:
+			parts = append(parts, m[1])
+		default:
+			parts = append(parts, m[0])
+		}
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("question %q: no output line matches %q", q.ID, q.Output)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// Normalize lowercases s and collapses runs of white space, so that
+// answers compare the way a person would read them.
+func Normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// Load reads and decodes m's manifest, rejecting unknown fields. It
+// returns an error wrapping fs.ErrNotExist for modules without one.
+func Load(m lab.Module) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(m.Dir, File))
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var man Manifest
+	if err := dec.Decode(&man); err != nil {
+		return nil, fmt.Errorf("%s/%s: %w", m.Name, File, err)
+	}
+	return &man, nil
+}
+
+// Validate checks man against m and the markers found in its sources,
+// reporting every problem rather than the first.
+func (man *Manifest) Validate(m lab.Module, found []markers.Marker) error {
+	var errs []error
+	bad := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s/%s: "+format, append([]any{m.Name, File}, args...)...))
+	}
+
+	if man.Title != m.Title {
+		bad("title %q does not match README title %q", man.Title, m.Title)
+	}
+	if strings.TrimSpace(man.Focus) == "" {
+		bad("focus is empty")
+	}
+	if _, err := golden.ParseMask(man.Mask); err != nil {
+		bad("%v", err)
+	}
+	runs := map[string]bool{golden.Main: true}
+	for _, r := range man.Runs {
+		switch {
+		case !m.IsMain():
+			bad("run %q in a module without a program", r.Name)
+		case !runNameRe.MatchString(r.Name):
+			bad("run name %q is not lower-case letters, digits and dashes", r.Name)
+		case runs[r.Name]:
+			bad("run name %q is taken", r.Name)
+		case r.Exit < 0 || r.Exit > 255:
+			bad("run %q: exit status %d is not 0 to 255", r.Name, r.Exit)
+		}
+		runs[r.Name] = true
+	}
+	if len(man.Breakpoints) == 0 {
+		bad("no breakpoints")
+	}
+	listed := map[string]bool{}
+	for _, bp := range man.Breakpoints {
+		mk, err := bp.Resolve(found)
+		if err != nil {
+			bad("breakpoint: %v", err)
+			continue
+		}
+		if listed[mk.Pos()] {
+			bad("breakpoint %s is listed twice", bp.Anchor)
+		}
+		listed[mk.Pos()] = true
+	}
+	for _, mk := range found {
+		if mk.Kind == markers.Breakpoint && !listed[mk.Pos()] && len(man.Breakpoints) > 0 {
+			bad("%s:%d: 🔍 marker in %s is not in breakpoints", mk.File, mk.Line, mk.Func)
+		}
+	}
+	for _, obs := range man.Observations {
+		if _, err := obs.Resolve(found); err != nil {
+			bad("observation: %v", err)
+		}
+		if len(obs.Expect) == 0 {
+			bad("observation %s expects nothing", obs)
+		}
+	}
+
+	for _, esc := range man.Escapes {
+		mk, err := esc.Resolve(found)
+		switch {
+		case err != nil:
+			bad("escape: %v", err)
+		case mk.Kind != markers.Question:
+			bad("escape %s: anchor is a %s marker, not 🤔", esc, mk.Kind)
+		}
+		if esc.Var == "" {
+			bad("escape %s names no variable", esc.Anchor)
+		}
+		if strings.TrimSpace(esc.Why) == "" {
+			bad("escape %s gives no reason", esc)
+		}
+	}
+	if len(man.Escapes) > 0 {
+		readme, err := os.ReadFile(filepath.Join(m.Dir, "README.md"))
+		if err != nil {
+			bad("%v", err)
+		} else if text, ok := escapeSection(readme); !ok {
+			bad("README.md has no escapes section")
+		} else if !bytes.Equal(text, EscapeText(man.Escapes)) {
+			bad("README.md escapes section is out of date: run go run ./labctl escape -readme")
+		}
+	}
+
+	ids := map[string]bool{}
+	for i, q := range man.Questions {
+		switch {
+		case q.ID == "":
+			bad("question %d has no id", i+1)
+		case ids[q.ID]:
+			bad("duplicate question id %q", q.ID)
+		}
+		ids[q.ID] = true
+		if strings.TrimSpace(q.Prompt) == "" {
+			bad("question %q has no prompt", q.ID)
+		}
+		switch {
+		case q.Output != "" && len(q.Answers) > 0:
+			bad("question %q: an output question takes its answer from the program, not answers", q.ID)
+		case q.Output != "" && !m.IsMain():
+			bad("question %q: output question in a module without a program", q.ID)
+		case q.Output != "":
+			if _, err := regexp.Compile(q.Output); err != nil {
+				bad("question %q: output: %v", q.ID, err)
+			}
+		case len(q.Answers) == 0:
+			bad("question %q has no accepted answers", q.ID)
+		}
+		if len(q.Choices) > 0 {
+			for _, a := range q.Answers {
+				if !slices.Contains(q.Choices, a) {
+					bad("question %q: answer %q is not one of its choices", q.ID, a)
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
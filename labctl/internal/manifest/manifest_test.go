@@ -0,0 +1,122 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"debugger-lab/labctl/internal/golden"
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/markers"
+)
+
+// TestModules validates every module's lab.json against its README and
+// sources, so moving or rewording a marker fails here rather than in a
+// debugger session.
+func TestModules(t *testing.T) {
+	root, err := lab.FindRoot(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mods, err := lab.Modules(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range mods {
+		man, err := Load(m)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		found, err := markers.ParseDir(m.Dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := man.Validate(m, found); err != nil {
+			t.Error(err)
+		}
+		checkOutputQuestions(t, m, man)
+	}
+}
+
+// checkOutputQuestions answers m's output questions from its golden
+// file, which holds the masked output the quiz compares against.
+func checkOutputQuestions(t *testing.T, m lab.Module, man *Manifest) {
+	t.Helper()
+	var out []byte
+	for _, q := range man.Questions {
+		if q.Output == "" {
+			continue
+		}
+		if out == nil {
+			var err error
+			if out, err = os.ReadFile(filepath.Join(m.Dir, filepath.FromSlash(golden.File))); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		got, err := q.Printed(out)
+		if err != nil {
+			t.Errorf("%s: %v", m.Name, err)
+			continue
+		}
+		if len(q.Choices) > 0 && !slices.Contains(q.Choices, got) {
+			t.Errorf("%s: question %q: the program prints %q, which is not one of its choices", m.Name, q.ID, got)
+		}
+	}
+}
+
+func TestAccepts(t *testing.T) {
+	q := Question{ID: "q", Prompt: "?", Answers: []string{"v=0, ok=false", "0 false"}}
+	for answer, want := range map[string]bool{
+		"v=0, ok=false":    true,
+		"  V=0,  OK=false": true,
+		"0 false":          true,
+		"0, false":         false,
+		"":                 false,
+	} {
+		if got := q.Accepts(answer); got != want {
+			t.Errorf("Accepts(%q) = %v, want %v", answer, got, want)
+		}
+	}
+}
+
+func TestPrinted(t *testing.T) {
+	out := []byte("Captured i: 0\nCaptured i: 1\nother\nCaptured i: 2\n")
+	for _, tt := range []struct{ output, want string }{
+		{`^Captured i: (\d+)$`, "0 1 2"},
+		{`^Captured i: 1$`, "Captured i: 1"},
+	} {
+		q := Question{ID: "q", Output: tt.output}
+		if got, err := q.Printed(out); err != nil || got != tt.want {
+			t.Errorf("Printed(%q) = %q, %v, want %q", tt.output, got, err, tt.want)
+		}
+	}
+	if _, err := (Question{ID: "q", Output: "^Released"}).Printed(out); err == nil {
+		t.Error("Printed succeeded without a matching line")
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	r := Run{Name: "env", Env: map[string]string{"GREET_NAME": "Grace", "GREET_LOUD": "true"}}
+	if got, want := r.Environ(), []string{"GREET_LOUD=true", "GREET_NAME=Grace"}; !slices.Equal(got, want) {
+		t.Errorf("Environ() = %q, want %q", got, want)
+	}
+}
+
+func TestEscapeSection(t *testing.T) {
+	escapes := []Escape{
+		{Anchor: Anchor{Func: "createPointer"}, Var: "local", Heap: true, Why: "it is returned"},
+		{Anchor: Anchor{Func: "createValue"}, Var: "local", Why: "only its value leaves"},
+	}
+	want := "- `createPointer`'s `local` is on the **heap**: it is returned\n" +
+		"- `createValue`'s `local` stays on the **stack**: only its value leaves\n"
+	readme := "It says:\n" + BeginEscapes + "\n" + want + EndEscapes + "\n"
+	if got, ok := escapeSection([]byte(readme)); !ok || string(got) != want {
+		t.Errorf("escapeSection = %q, %v, want %q", got, ok, want)
+	}
+	if got := string(EscapeText(escapes)); got != want {
+		t.Errorf("EscapeText = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,469 @@
+// Package markers extracts the emoji marker comments that annotate the
+// lab sources (🔍 SET BREAKPOINT HERE, 👀 WATCH, ⚠️, 🤔) and resolves each
+// one to the executable line and function a debugger would stop in.
+package markers
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind classifies a marker comment by its emoji.
+type Kind int
+
+const (
+	Breakpoint Kind = iota // 🔍 SET BREAKPOINT HERE, 🔍 SET CONDITIONAL BREAKPOINT
+	Step                   // any other 🔍 hint, e.g. "🔍 Step Into (F11) here"
+	Watch                  // 👀
+	Warning                // ⚠️
+	Question               // 🤔
+)
+
+var kindNames = [...]string{"breakpoint", "step", "watch", "warning", "question"}
+
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Marker is one marker comment resolved against the surrounding code.
+type Marker struct {
+	Kind Kind
+	File string // slash-separated path relative to the scanned directory
+	Line int    // line of the marker comment itself
+
+	// Text is the comment after the emoji, e.g.
+	// "SET BREAKPOINT HERE — Right before exit".
+	Text string
+	// Note is the explanation after the em dash, or the whole Text for
+	// markers without a fixed prefix.
+	Note string
+	// Condition is set for 🔍 SET CONDITIONAL BREAKPOINT markers.
+	Condition string
+
+	// Func is the enclosing (or, for doc comments, following) function
+	// as the runtime and Delve name it, without the package qualifier:
+	// "main", "init.0", "(*Counter).IncrementPointer", "TestAdd.func1".
+	// It is empty for markers outside any function.
+	Func string
+	// StmtLine is the line a breakpoint for this marker belongs on: the
+	// statement the comment trails, the next statement after it, or the
+	// closing brace when nothing follows in the function.
+	StmtLine int
+	// Stmt is the source of StmtLine without indentation or comments.
+	Stmt string
+	// Vars lists the expressions in Text, such as "x" or "tt.a", whose
+	// root identifier is a variable, parameter, package-level name or
+	// import visible in Func.
+	Vars []string
+}
+
+// Pos formats the marker's statement as a file:line location.
+func (m Marker) Pos() string {
+	return fmt.Sprintf("%s:%d", m.File, m.StmtLine)
+}
+
+const (
+	breakpointPrefix  = "SET BREAKPOINT HERE"
+	conditionalPrefix = "SET CONDITIONAL BREAKPOINT"
+)
+
+var emojis = []struct {
+	emoji string
+	kind  Kind
+}{
+	{"🔍", Step},
+	{"👀", Watch},
+	{"⚠", Warning}, // with or without the U+FE0F variation selector
+	{"🤔", Question},
+}
+
+// ParseDir extracts the markers of every Go package under dir, skipping
+// testdata directories. Markers are ordered by file and line.
+func ParseDir(dir string) ([]Marker, error) {
+	byDir := map[string][]string{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path != dir && (d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".")) {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".go") {
+			byDir[filepath.Dir(path)] = append(byDir[filepath.Dir(path)], path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Marker
+	for _, files := range byDir {
+		sort.Strings(files)
+		found, err := parsePackage(dir, files)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, found...)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].File != all[j].File {
+			return all[i].File < all[j].File
+		}
+		return all[i].Line < all[j].Line
+	})
+	return all, nil
+}
+
+// ParseFile extracts the markers of a single file. File names are
+// reported as given.
+func ParseFile(filename string, src []byte) ([]Marker, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var inits int
+	return fileMarkers(fset, f, src, filename, &inits), nil
+}
+
+// parsePackage parses files (one directory, sorted by name, the order
+// the compiler numbers init functions in) and reports paths relative to
+// root.
+func parsePackage(root string, files []string) ([]Marker, error) {
+	fset := token.NewFileSet()
+	inits := map[string]*int{} // package name → init functions seen
+	var all []Marker
+	for _, path := range files {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil, err
+		}
+		n := inits[f.Name.Name]
+		if n == nil {
+			n = new(int)
+			inits[f.Name.Name] = n
+		}
+		all = append(all, fileMarkers(fset, f, src, filepath.ToSlash(rel), n)...)
+	}
+	return all, nil
+}
+
+// funcScope is a function body with its runtime name.
+type funcScope struct {
+	name           string
+	lbrace, rbrace token.Pos
+	stmts          []ast.Stmt      // executable statements in source order
+	names          map[string]bool // identifiers declared in or above the body
+}
+
+// exprRe matches identifiers and selector/index chains like tt.a or
+// os.Args[0] in marker text.
+var exprRe = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*|\[[0-9]+\])*`)
+
+// vars returns the expressions in text that start with a name visible in
+// scope, in order of appearance.
+func vars(text string, scope *funcScope, pkg map[string]bool) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, expr := range exprRe.FindAllString(text, -1) {
+		root := expr
+		if i := strings.IndexAny(expr, ".["); i >= 0 {
+			root = expr[:i]
+		}
+		visible := pkg[root] || (scope != nil && scope.names[root])
+		if !visible || seen[expr] {
+			continue
+		}
+		seen[expr] = true
+		out = append(out, expr)
+	}
+	return out
+}
+
+func fileMarkers(fset *token.FileSet, f *ast.File, src []byte, filename string, inits *int) []Marker {
+	scopes := collectScopes(f, inits)
+	pkg := packageNames(f)
+	lines := bytes.Split(src, []byte("\n"))
+	line := func(p token.Pos) int { return fset.Position(p).Line }
+
+	// Comments by line, so statement text can be cut before them.
+	commentCol := map[int]int{}
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			p := fset.Position(c.Pos())
+			if col, ok := commentCol[p.Line]; !ok || p.Column < col {
+				commentCol[p.Line] = p.Column
+			}
+		}
+	}
+	stmtText := func(n int) string {
+		if n < 1 || n > len(lines) {
+			return ""
+		}
+		text := lines[n-1]
+		if col, ok := commentCol[n]; ok && col-1 <= len(text) {
+			text = text[:col-1]
+		}
+		return strings.TrimSpace(string(text))
+	}
+
+	var out []Marker
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			m, ok := parseComment(c.Text)
+			if !ok {
+				continue
+			}
+			m.File = filename
+			m.Line = line(c.Pos())
+			scope, stmtLine := resolve(fset, scopes, c)
+			if scope != nil {
+				m.Func = scope.name
+			}
+			m.StmtLine = stmtLine
+			m.Stmt = stmtText(m.StmtLine)
+			m.Vars = vars(m.Text, scope, pkg)
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// parseComment recognizes a marker in the raw text of one comment.
+func parseComment(raw string) (Marker, bool) {
+	text := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(raw, "//"), "/*"))
+	text = strings.TrimSpace(strings.TrimSuffix(text, "*/"))
+	// The first emoji in the comment decides its kind.
+	at, kind, width := -1, Kind(0), 0
+	for _, e := range emojis {
+		if i := strings.Index(text, e.emoji); i >= 0 && (at < 0 || i < at) {
+			at, kind, width = i, e.kind, len(e.emoji)
+		}
+	}
+	if at < 0 {
+		return Marker{}, false
+	}
+	m := Marker{Kind: kind}
+	m.Text = strings.TrimSpace(strings.TrimPrefix(text[at+width:], "\uFE0F"))
+	m.Note = m.Text
+	switch {
+	case kind == Step && strings.HasPrefix(m.Text, conditionalPrefix):
+		m.Kind = Breakpoint
+		m.Condition = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(m.Text, conditionalPrefix), ":"))
+		m.Note = ""
+	case kind == Step && strings.HasPrefix(m.Text, breakpointPrefix):
+		m.Kind = Breakpoint
+		m.Note = ""
+		if _, note, ok := strings.Cut(m.Text, "—"); ok {
+			m.Note = strings.TrimSpace(note)
+		}
+	}
+	return m, true
+}
+
+// resolve finds the function and executable line a marker comment
+// refers to. The scope is nil for comments outside any function.
+func resolve(fset *token.FileSet, scopes []*funcScope, c *ast.Comment) (*funcScope, int) {
+	line := func(p token.Pos) int { return fset.Position(p).Line }
+	cline := line(c.Pos())
+
+	// Innermost function whose body contains the comment.
+	var inner *funcScope
+	for _, s := range scopes {
+		if s.lbrace < c.Pos() && c.Pos() < s.rbrace {
+			if inner == nil || s.lbrace > inner.lbrace {
+				inner = s
+			}
+		}
+	}
+	if inner != nil {
+		for _, st := range inner.stmts {
+			if line(st.Pos()) >= cline {
+				return inner, line(st.Pos())
+			}
+		}
+		return inner, line(inner.rbrace)
+	}
+
+	// A doc comment: the first statement of the next function.
+	var next *funcScope
+	for _, s := range scopes {
+		if s.lbrace > c.Pos() && (next == nil || s.lbrace < next.lbrace) {
+			next = s
+		}
+	}
+	if next == nil {
+		return nil, cline
+	}
+	if len(next.stmts) > 0 {
+		return next, line(next.stmts[0].Pos())
+	}
+	return next, line(next.rbrace)
+}
+
+// collectScopes names every function body in f the way the runtime
+// does: methods as (*T).M or T.M, init functions as init.N, generic
+// functions as F[...], and closures as parent.funcN (top level) or
+// parent.N (nested), numbered in source order.
+func collectScopes(f *ast.File, inits *int) []*funcScope {
+	var scopes []*funcScope
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			continue
+		}
+		name := fd.Name.Name
+		if fd.Recv == nil && name == "init" {
+			name = fmt.Sprintf("init.%d", *inits)
+			*inits++
+		} else if fd.Recv != nil && len(fd.Recv.List) == 1 {
+			name = recvName(fd.Recv.List[0].Type) + "." + name
+		} else if fd.Type.TypeParams != nil {
+			name += "[...]"
+		}
+		names := map[string]bool{}
+		declare(names, fd.Recv)
+		declare(names, fd.Type.TypeParams)
+		declare(names, fd.Type.Params)
+		declare(names, fd.Type.Results)
+		scopes = append(scopes, bodyScopes(name, "func", fd.Body, names)...)
+	}
+	return scopes
+}
+
+// bodyScopes returns the scope of body and of every closure inside it.
+// Closures directly inside a declared function are named name.funcN;
+// closures inside another closure are named name.N.
+// names holds the parameters of the function; closures also see every
+// name of their parent.
+func bodyScopes(name, closurePrefix string, body *ast.BlockStmt, names map[string]bool) []*funcScope {
+	scope := &funcScope{name: name, lbrace: body.Lbrace, rbrace: body.Rbrace, names: names}
+	scopes := []*funcScope{scope}
+	var closures []*ast.FuncLit
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			closures = append(closures, node)
+			return false
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE {
+				for _, lhs := range node.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						names[id.Name] = true
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if node.Tok == token.DEFINE {
+				for _, e := range []ast.Expr{node.Key, node.Value} {
+					if id, ok := e.(*ast.Ident); ok {
+						names[id.Name] = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, id := range node.Names {
+				names[id.Name] = true
+			}
+		case *ast.TypeSwitchStmt:
+			if a, ok := node.Assign.(*ast.AssignStmt); ok {
+				for _, lhs := range a.Lhs {
+					names[lhs.(*ast.Ident).Name] = true
+				}
+			}
+		}
+		switch node := node.(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause, *ast.LabeledStmt, *ast.EmptyStmt:
+			// Not a line of its own; the statements inside are.
+		case ast.Stmt:
+			scope.stmts = append(scope.stmts, node)
+		}
+		return true
+	})
+	sort.SliceStable(scope.stmts, func(i, j int) bool { return scope.stmts[i].Pos() < scope.stmts[j].Pos() })
+
+	// Name closures only after the whole body has been seen, so each one
+	// inherits every name of its parent.
+	for i, lit := range closures {
+		child := fmt.Sprintf("%s.%s%d", name, closurePrefix, i+1)
+		inner := maps.Clone(names)
+		declare(inner, lit.Type.Params)
+		declare(inner, lit.Type.Results)
+		scopes = append(scopes, bodyScopes(child, "", lit.Body, inner)...)
+	}
+	return scopes
+}
+
+// declare adds the names in fields to names.
+func declare(names map[string]bool, fields *ast.FieldList) {
+	if fields == nil {
+		return
+	}
+	for _, f := range fields.List {
+		for _, id := range f.Names {
+			names[id.Name] = true
+		}
+	}
+}
+
+// packageNames returns the package-level variables, constants and
+// imports of f.
+func packageNames(f *ast.File) map[string]bool {
+	names := map[string]bool{}
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		names[name] = true
+	}
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || (gd.Tok != token.VAR && gd.Tok != token.CONST) {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			for _, id := range spec.(*ast.ValueSpec).Names {
+				names[id.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+func recvName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return "(*" + recvName(t.X) + ")"
+	case *ast.IndexExpr:
+		return recvName(t.X) + "[...]"
+	case *ast.IndexListExpr:
+		return recvName(t.X) + "[...]"
+	case *ast.Ident:
+		return t.Name
+	}
+	return "?"
+}
@@ -0,0 +1,62 @@
+package markers
+
+import "testing"
+
+const src = `package main
+
+import "fmt"
+
+type Counter struct{ value int }
+
+// 🔍 SET BREAKPOINT HERE — pointer receiver
+func (c *Counter) Inc() {
+	c.value++ // ⚠️ modifies the original
+}
+
+// 🔍 SET BREAKPOINT HERE
+func init() {
+	fmt.Println("init")
+}
+
+func main() {
+	// 🔍 SET BREAKPOINT HERE
+	x := 10
+	fmt.Println(x) // 👀 x = 10
+
+	for i := 0; i < 3; i++ {
+		// 🔍 SET CONDITIONAL BREAKPOINT: i == 2
+		defer func() {
+			// 🔍 SET BREAKPOINT HERE — inside the closure
+			fmt.Println(i)
+		}()
+	}
+	// 🔍 SET BREAKPOINT HERE — end of main
+}
+`
+
+func TestParseFile(t *testing.T) {
+	got, err := ParseFile("main.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Marker{
+		{Kind: Breakpoint, Line: 7, Note: "pointer receiver", Func: "(*Counter).Inc", StmtLine: 9, Stmt: "c.value++"},
+		{Kind: Warning, Line: 9, Note: "modifies the original", Func: "(*Counter).Inc", StmtLine: 9, Stmt: "c.value++"},
+		{Kind: Breakpoint, Line: 12, Func: "init.0", StmtLine: 14, Stmt: `fmt.Println("init")`},
+		{Kind: Breakpoint, Line: 18, Func: "main", StmtLine: 19, Stmt: "x := 10"},
+		{Kind: Watch, Line: 20, Note: "x = 10", Func: "main", StmtLine: 20, Stmt: "fmt.Println(x)"},
+		{Kind: Breakpoint, Line: 23, Condition: "i == 2", Func: "main", StmtLine: 24, Stmt: "defer func() {"},
+		{Kind: Breakpoint, Line: 25, Note: "inside the closure", Func: "main.func1", StmtLine: 26, Stmt: "fmt.Println(i)"},
+		{Kind: Breakpoint, Line: 29, Note: "end of main", Func: "main", StmtLine: 30, Stmt: "}"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d markers, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Kind != w.Kind || g.Line != w.Line || g.Note != w.Note || g.Condition != w.Condition ||
+			g.Func != w.Func || g.StmtLine != w.StmtLine || g.Stmt != w.Stmt {
+			t.Errorf("marker %d:\n got  %+v\n want %+v", i, g, w)
+		}
+	}
+}
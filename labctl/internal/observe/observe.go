@@ -0,0 +1,201 @@
+// Package observe checks the values the lab promises students will see
+// (`fmt.Println("Outer x:", x) // 👀 x = 10`) against a real Delve
+// session, so a Go or Delve upgrade cannot silently change them.
+//
+// The checks are the observations in each module's lab.json (see
+// package manifest): Run stops at the line each observation's marker
+// resolves to and evaluates its expressions there.
+package observe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"debugger-lab/labctl/internal/dlv"
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/manifest"
+	"debugger-lab/labctl/internal/markers"
+)
+
+// Result is the outcome of one observation.
+type Result struct {
+	Observation manifest.Observation
+	Marker      markers.Marker    // the marker the observation resolved to
+	Got         map[string]string // expression → value Delve reported
+	Err         error             // set when the stop was never reached or evaluation failed
+}
+
+// Mismatches lists the expressions whose value differs from the
+// expectation, formatted as "expr = got, want want".
+func (r Result) Mismatches() []string {
+	var out []string
+	for _, expr := range sortedKeys(r.Observation.Expect) {
+		want := r.Observation.Expect[expr]
+		if got, ok := r.Got[expr]; ok && got != want {
+			out = append(out, fmt.Sprintf("%s = %s, want %s", expr, got, want))
+		}
+	}
+	return out
+}
+
+// Failed reports whether the observation did not hold.
+func (r Result) Failed() bool {
+	return r.Err != nil || len(r.Mismatches()) > 0
+}
+
+// stop is a breakpoint shared by the observations that resolve to the
+// same line.
+type stop struct {
+	marker  markers.Marker
+	results []*Result
+}
+
+// Run builds m with optimizations disabled, starts it under a headless
+// dlv with man.Args, and continues from stop to stop evaluating every
+// observation of man. Build and debugger output goes to log.
+func Run(m lab.Module, man *manifest.Manifest, log io.Writer) ([]Result, error) {
+	found, err := markers.ParseDir(m.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, len(man.Observations))
+	stops := map[string]*stop{} // file:line → stop
+	for i, obs := range man.Observations {
+		mk, err := obs.Resolve(found)
+		if err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", m.Name, manifest.File, err)
+		}
+		results[i].Observation = obs
+		results[i].Marker = mk
+		s := stops[mk.Pos()]
+		if s == nil {
+			s = &stop{marker: mk}
+			stops[mk.Pos()] = s
+		}
+		s.results = append(s.results, &results[i])
+	}
+
+	tmp, err := os.MkdirTemp("", "labctl-observe-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	bin := filepath.Join(tmp, m.Name)
+	if err := build(m, bin, log); err != nil {
+		return nil, err
+	}
+
+	server, err := dlv.Exec(bin, m.Dir, man.Args, log)
+	if err != nil {
+		return nil, err
+	}
+	defer server.Close()
+	client, err := dlv.Dial(server.Addr)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Detach(true)
+
+	byID := map[int]*stop{}
+	for _, s := range stops {
+		bp, err := client.CreateBreakpoint(dlv.Breakpoint{
+			File: filepath.Join(m.Dir, filepath.FromSlash(s.marker.File)),
+			Line: s.marker.StmtLine,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("breakpoint at %s: %w", s.marker.Pos(), err)
+		}
+		byID[bp.ID] = s
+	}
+
+	pending := len(results)
+	hits := map[int]int{}
+	for pending > 0 {
+		state, err := client.Continue()
+		if err != nil {
+			return nil, err
+		}
+		if state.Exited {
+			break
+		}
+		th := state.CurrentThread
+		if th == nil || th.Breakpoint == nil {
+			continue
+		}
+		if th.Breakpoint.ID < 0 {
+			// Delve's built-in unrecovered-panic and fatal-throw
+			// breakpoints.
+			return nil, fmt.Errorf("%s stopped at %s:%d: %s", m.Name, th.File, th.Line, th.Breakpoint.Name)
+		}
+		s := byID[th.Breakpoint.ID]
+		if s == nil {
+			continue
+		}
+		hits[th.Breakpoint.ID]++
+		for _, r := range s.results {
+			if max(r.Observation.Hit, 1) != hits[th.Breakpoint.ID] {
+				continue
+			}
+			r.Got = map[string]string{}
+			for _, expr := range sortedKeys(r.Observation.Expect) {
+				v, err := client.Eval(expr)
+				if err != nil {
+					r.Err = fmt.Errorf("eval %s: %w", expr, err)
+					break
+				}
+				r.Got[expr] = v.String()
+			}
+			pending--
+		}
+	}
+
+	for i := range results {
+		if results[i].Got == nil && results[i].Err == nil {
+			results[i].Err = fmt.Errorf("never stopped at %s", results[i].Marker.Pos())
+		}
+	}
+	return results, nil
+}
+
+// build compiles m the way the debug launch configurations do; test-only
+// modules are compiled into a test binary.
+func build(m lab.Module, bin string, log io.Writer) error {
+	args := []string{"build", "-gcflags=" + lab.DebugGCFlags, "-o", bin, "."}
+	if !m.IsMain() {
+		args = []string{"test", "-c", "-gcflags=" + lab.DebugGCFlags, "-o", bin, "."}
+	}
+	cmd := exec.Command("go", args...)
+	cmd.Dir = m.Dir
+	cmd.Stdout = log
+	cmd.Stderr = log
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("building %s: %w", m.Name, err)
+	}
+	return nil
+}
+
+// ErrNoDelve is returned by Available when dlv is not installed.
+var ErrNoDelve = errors.New("dlv not found in PATH: go install github.com/go-delve/delve/cmd/dlv@latest")
+
+// Available reports whether dlv can be started.
+func Available() error {
+	if _, err := exec.LookPath("dlv"); err != nil {
+		return ErrNoDelve
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,58 @@
+package observe
+
+import (
+	"strings"
+	"testing"
+
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/manifest"
+)
+
+func modules(t *testing.T) []lab.Module {
+	t.Helper()
+	root, err := lab.FindRoot(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mods, err := lab.Modules(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mods
+}
+
+// TestExpectations runs every module's lab.json observations under
+// Delve.
+// It needs dlv in PATH and is skipped with -short.
+func TestExpectations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a debugger for every module")
+	}
+	if err := Available(); err != nil {
+		t.Skip(err)
+	}
+	for _, m := range modules(t) {
+		man, err := manifest.Load(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(man.Observations) == 0 {
+			continue
+		}
+		t.Run(m.Name, func(t *testing.T) {
+			var log strings.Builder
+			results, err := Run(m, man, &log)
+			if err != nil {
+				t.Fatalf("%v\n%s", err, log.String())
+			}
+			for _, r := range results {
+				if r.Err != nil {
+					t.Errorf("%s: %v", r.Observation, r.Err)
+				}
+				for _, mm := range r.Mismatches() {
+					t.Errorf("%s at %s: %s", r.Observation, r.Marker.Pos(), mm)
+				}
+			}
+		})
+	}
+}
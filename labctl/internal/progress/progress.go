@@ -0,0 +1,190 @@
+// Package progress keeps a learner's results across lab sessions in a
+// JSON file under the user config directory, so that each session picks
+// up where the last one stopped.
+//
+// The file is a log: quiz runs, observation checks and the time spent in
+// labctl run, debug and quiz sessions are appended as they happen, and
+// Summarize condenses them per module.
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// EnvFile names an environment variable that, when set, overrides the
+// location of the progress file.
+const EnvFile = "LABCTL_PROGRESS"
+
+// Path returns the progress file: $LABCTL_PROGRESS, or
+// debugger-lab/progress.json under os.UserConfigDir.
+func Path() (string, error) {
+	if p := os.Getenv(EnvFile); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "debugger-lab", "progress.json"), nil
+}
+
+// Store is the content of the progress file.
+type Store struct {
+	// Learner names the person in reports and exports; empty means the
+	// login name.
+	Learner  string    `json:"learner,omitempty"`
+	Quizzes  []Quiz    `json:"quizzes,omitempty"`
+	Checks   []Check   `json:"checks,omitempty"`
+	Sessions []Session `json:"sessions,omitempty"`
+}
+
+// Quiz is one completed run of `labctl quiz`.
+type Quiz struct {
+	Module string    `json:"module"`
+	Time   time.Time `json:"time"`
+	Score  int       `json:"score"`
+	Total  int       `json:"total"`
+	Missed []string  `json:"missed,omitempty"` // ids of the questions answered wrong
+}
+
+// Passed reports whether every question was answered right.
+func (q Quiz) Passed() bool { return q.Total > 0 && q.Score == q.Total }
+
+// Check is one run of `labctl check` over a module's observations.
+type Check struct {
+	Module string    `json:"module"`
+	Time   time.Time `json:"time"`
+	Total  int       `json:"total"`
+	Failed []string  `json:"failed,omitempty"` // the observations that did not hold, with what was seen
+}
+
+// Passed reports whether every observation held.
+func (c Check) Passed() bool { return len(c.Failed) == 0 }
+
+// Session is time spent on a module in one labctl command.
+type Session struct {
+	Module  string    `json:"module"`
+	Command string    `json:"command"` // "run", "debug" or "quiz"
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+// Summary is what a store says about one module.
+type Summary struct {
+	Module    string
+	BestQuiz  *Quiz  // highest score, the latest among equals; nil before any quiz
+	Attempts  int    // quiz runs
+	LastCheck *Check // nil if never checked
+	Time      time.Duration
+}
+
+// Completed reports whether the module is done: its quiz has been
+// passed and its observations, if checked, held the last time.
+func (s Summary) Completed() bool {
+	return s.BestQuiz != nil && s.BestQuiz.Passed() && (s.LastCheck == nil || s.LastCheck.Passed())
+}
+
+// Status describes the module's state in a word or two.
+func (s Summary) Status() string {
+	switch {
+	case s.Completed():
+		return "completed"
+	case s.LastCheck != nil && !s.LastCheck.Passed():
+		return "checks failing"
+	case s.BestQuiz != nil || s.LastCheck != nil || s.Time > 0:
+		return "in progress"
+	}
+	return "not started"
+}
+
+// Summarize condenses the store for each of modules, in order. Entries
+// for other modules, such as ones since renamed, are ignored.
+func (s *Store) Summarize(modules []string) []Summary {
+	sums := make([]Summary, len(modules))
+	index := map[string]*Summary{}
+	for i, m := range modules {
+		sums[i].Module = m
+		index[m] = &sums[i]
+	}
+	for i, q := range s.Quizzes {
+		sum := index[q.Module]
+		if sum == nil {
+			continue
+		}
+		sum.Attempts++
+		if sum.BestQuiz == nil || q.Score >= sum.BestQuiz.Score {
+			sum.BestQuiz = &s.Quizzes[i]
+		}
+	}
+	for i, c := range s.Checks {
+		if sum := index[c.Module]; sum != nil && (sum.LastCheck == nil || !c.Time.Before(sum.LastCheck.Time)) {
+			sum.LastCheck = &s.Checks[i]
+		}
+	}
+	for _, ses := range s.Sessions {
+		if sum := index[ses.Module]; sum != nil && ses.End.After(ses.Start) {
+			sum.Time += ses.End.Sub(ses.Start)
+		}
+	}
+	return sums
+}
+
+// Load reads the store at path; a missing file is an empty store.
+func Load(path string) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Store{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s Store
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Save writes s to path, replacing the file only once it is complete.
+func (s *Store) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".progress-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Update loads the store at Path, applies f and saves the result.
+func Update(f func(s *Store)) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	s, err := Load(path)
+	if err != nil {
+		return err
+	}
+	f(s)
+	return s.Save(path)
+}
@@ -0,0 +1,72 @@
+package progress
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	t0 := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	s := &Store{
+		Quizzes: []Quiz{
+			{Module: "01-main", Time: t0, Score: 3, Total: 5, Missed: []string{"a", "b"}},
+			{Module: "01-main", Time: t0.Add(time.Hour), Score: 5, Total: 5},
+			{Module: "02-scope", Time: t0, Score: 5, Total: 5},
+			{Module: "99-gone", Time: t0, Score: 1, Total: 1},
+		},
+		Checks: []Check{
+			{Module: "02-scope", Time: t0.Add(2 * time.Hour), Total: 3},
+			{Module: "02-scope", Time: t0.Add(time.Hour), Total: 3, Failed: []string{"main \"x\": x = 3"}},
+			{Module: "03-stack", Time: t0, Total: 2, Failed: []string{"f"}},
+		},
+		Sessions: []Session{
+			{Module: "01-main", Command: "debug", Start: t0, End: t0.Add(20 * time.Minute)},
+			{Module: "01-main", Command: "quiz", Start: t0, End: t0.Add(5 * time.Minute)},
+			{Module: "04-ptr", Command: "run", Start: t0, End: t0.Add(time.Minute)},
+		},
+	}
+	sums := s.Summarize([]string{"01-main", "02-scope", "03-stack", "04-ptr", "05-slices"})
+
+	want := []struct {
+		status   string
+		attempts int
+		time     time.Duration
+	}{
+		{"completed", 2, 25 * time.Minute},
+		{"completed", 1, 0}, // the later check passed
+		{"checks failing", 0, 0},
+		{"in progress", 0, time.Minute},
+		{"not started", 0, 0},
+	}
+	for i, w := range want {
+		got := sums[i]
+		if got.Status() != w.status || got.Attempts != w.attempts || got.Time != w.time {
+			t.Errorf("%s: status %q, %d attempts, %v; want %q, %d, %v",
+				got.Module, got.Status(), got.Attempts, got.Time, w.status, w.attempts, w.time)
+		}
+	}
+	if q := sums[0].BestQuiz; q == nil || q.Score != 5 {
+		t.Errorf("best quiz of 01-main = %+v, want score 5", q)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "progress.json")
+	s, err := Load(path)
+	if err != nil || len(s.Quizzes) != 0 {
+		t.Fatalf("Load of a missing file = %+v, %v", s, err)
+	}
+	s.Learner = "ada"
+	s.Quizzes = append(s.Quizzes, Quiz{Module: "01-main", Score: 1, Total: 2, Missed: []string{"q"}})
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Learner != "ada" || len(got.Quizzes) != 1 || got.Quizzes[0].Missed[0] != "q" {
+		t.Errorf("Load after Save = %+v", got)
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/manifest"
+)
+
+// launchFile is the VS Code launch configuration, relative to the root.
+const launchFile = ".vscode/launch.json"
+
+// generatedNameRe matches the names of the configurations runLaunch
+// writes. Configurations with any other name belong to the user.
+var generatedNameRe = regexp.MustCompile(`^(Debug|Test|Optimized|Attach to) Module \d{2} \(`)
+
+const launchHeader = `// Module configurations ("Debug Module NN", "Test Module NN",
+// "Optimized Module NN", "Attach to Module NN") are generated by
+// ` + "`go run ./labctl launch`" + ` from the module directories. Add your own
+// configurations under any other name: they are kept when the file is
+// regenerated, but comments are not.
+`
+
+// launchConfig is one generated entry of launch.json.
+type launchConfig struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Request    string            `json:"request"`
+	Mode       string            `json:"mode"`
+	Program    string            `json:"program,omitempty"`
+	Cwd        string            `json:"cwd,omitempty"`
+	Args       []string          `json:"args,omitempty"`
+	Env        map[string]string `json:"env,omitempty"`
+	BuildFlags *string           `json:"buildFlags,omitempty"`
+	Host       string            `json:"host,omitempty"`
+	Port       int               `json:"port,omitempty"`
+}
+
+// runLaunch regenerates the module configurations in .vscode/launch.json.
+// With -check it only reports whether the file is current.
+func runLaunch(e *env, args []string) error {
+	fs := flag.NewFlagSet("launch", flag.ContinueOnError)
+	check := fs.Bool("check", false, "fail if launch.json does not match the modules")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New("launch takes no module arguments")
+	}
+
+	path := filepath.Join(e.root, filepath.FromSlash(launchFile))
+	old, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	runs := map[string][]manifest.Run{}
+	for _, m := range e.modules {
+		man, err := manifest.Load(m)
+		if err != nil {
+			return err
+		}
+		runs[m.Name] = man.Runs
+	}
+	data, err := renderLaunch(old, e.modules, runs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", launchFile, err)
+	}
+	if bytes.Equal(old, data) {
+		return nil
+	}
+	if *check {
+		return fmt.Errorf("out of date: %s: run go run ./labctl launch", launchFile)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Println("wrote", launchFile)
+	return nil
+}
+
+// moduleConfigs lists the generated configurations: a debug,
+// optimized-compare and attach entry for every module, a debug entry
+// for each of its lab.json runs, such as "Debug Module 14
+// (exit-paths): exit", and a test entry for programs that also
+// have tests. Test-only modules such as 13-debugging-tests are debugged
+// in test mode to begin with.
+func moduleConfigs(mods []lab.Module, runs map[string][]manifest.Run) []launchConfig {
+	debugFlags := `-gcflags="` + lab.DebugGCFlags + `"`
+	// Delve adds -gcflags='all=-N -l' to every build itself; only an
+	// explicit empty setting turns optimizations back on.
+	optimizedFlags := "-gcflags=all="
+
+	var debug, test, optimized, attach []launchConfig
+	for _, m := range mods {
+		suffix := fmt.Sprintf("Module %02d (%s)", m.Number, m.Slug())
+		program := "${workspaceFolder}/" + m.Name
+		mode := "debug"
+		if !m.IsMain() {
+			mode = "test"
+		}
+		debug = append(debug, launchConfig{
+			Name: "Debug " + suffix, Type: "go", Request: "launch",
+			Mode: mode, Program: program, BuildFlags: &debugFlags,
+		})
+		for _, r := range runs[m.Name] {
+			// The run's arguments may name files in the module, so it
+			// runs in the module directory.
+			debug = append(debug, launchConfig{
+				Name: "Debug " + suffix + ": " + r.Name, Type: "go", Request: "launch",
+				Mode: mode, Program: program, Cwd: program, Args: r.Args, Env: r.Env, BuildFlags: &debugFlags,
+			})
+		}
+		if m.IsMain() && m.HasTests() {
+			test = append(test, launchConfig{
+				Name: "Test " + suffix, Type: "go", Request: "launch",
+				Mode: "test", Program: program, BuildFlags: &debugFlags,
+			})
+		}
+		optimized = append(optimized, launchConfig{
+			Name: "Optimized " + suffix, Type: "go", Request: "launch",
+			Mode: mode, Program: program, BuildFlags: &optimizedFlags,
+		})
+		attach = append(attach, launchConfig{
+			Name: "Attach to " + suffix, Type: "go", Request: "attach",
+			Mode: "remote", Host: "127.0.0.1", Port: m.DebugPort(),
+		})
+	}
+	return append(append(append(debug, test...), optimized...), attach...)
+}
+
+// renderLaunch rewrites the launch.json in old with fresh module
+// configurations. They take the place of the first generated entry;
+// every other entry keeps its content and relative order.
+func renderLaunch(old []byte, mods []lab.Module, runs map[string][]manifest.Run) ([]byte, error) {
+	var doc struct {
+		Version        string            `json:"version"`
+		Configurations []json.RawMessage `json:"configurations"`
+	}
+	if len(old) > 0 {
+		if err := json.Unmarshal(stripJSONC(old), &doc); err != nil {
+			return nil, err
+		}
+	}
+	if doc.Version == "" {
+		doc.Version = "0.2.0"
+	}
+
+	var before, after []json.RawMessage
+	seen := false
+	for _, raw := range doc.Configurations {
+		var c struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return nil, err
+		}
+		switch {
+		case generatedNameRe.MatchString(c.Name):
+			seen = true
+		case seen:
+			after = append(after, raw)
+		default:
+			before = append(before, raw)
+		}
+	}
+
+	entries := before
+	for _, c := range moduleConfigs(mods, runs) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(c); err != nil {
+			return nil, err
+		}
+		entries = append(entries, bytes.TrimSpace(buf.Bytes()))
+	}
+	entries = append(entries, after...)
+
+	var out bytes.Buffer
+	out.WriteString(launchHeader)
+	fmt.Fprintf(&out, "{\n    \"version\": %q,\n    \"configurations\": [\n", doc.Version)
+	for i, raw := range entries {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			return nil, err
+		}
+		out.WriteString("        ")
+		if err := json.Indent(&out, compact.Bytes(), "        ", "    "); err != nil {
+			return nil, err
+		}
+		if i < len(entries)-1 {
+			out.WriteByte(',')
+		}
+		out.WriteByte('\n')
+	}
+	out.WriteString("    ]\n}\n")
+	return out.Bytes(), nil
+}
+
+// stripJSONC turns VS Code's JSON with comments into plain JSON by
+// removing // and /* */ comments and trailing commas.
+func stripJSONC(src []byte) []byte {
+	var out []byte
+	inString := false
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(src) {
+				i++
+				out = append(out, src[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			out = append(out, '\n')
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := bytes.Index(src[i+2:], []byte("*/"))
+			if end < 0 {
+				return out
+			}
+			i += end + 3
+		case c == ']' || c == '}':
+			// Drop a comma that only white space separates from here.
+			j := len(out) - 1
+			for j >= 0 && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
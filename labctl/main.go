@@ -0,0 +1,174 @@
+// labctl is the single entry point for the debugger lab. It lists the
+// numbered modules and runs, tests or debugs any of them from the
+// repository root, so nobody has to cd into NN-* by hand.
+//
+//	go run ./labctl list
+//	go run ./labctl run 02
+//	go run ./labctl debug 13 -- -test.run TestAdd
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"debugger-lab/labctl/internal/lab"
+)
+
+// env is what every command needs: the repository root and its modules.
+type env struct {
+	root    string
+	modules []lab.Module
+}
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(e *env, args []string) error
+}
+
+var commands = []command{
+	{"list", "", "list the lab modules", runList},
+	{"run", "<module> [-- args]", "go run the module's program", runRun},
+	{"test", "<module> [-- go test flags]", "go test the module", runTest},
+	{"debug", "[-headless] <module> [-- args]", "start dlv with optimizations disabled", runDebug},
+	{"clean", "[-n] [module...]", "remove __debug_bin* and other built binaries", runClean},
+	{"markers", "<module>", "list marker comments and where they resolve", runMarkers},
+	{"breakpoints", "[-check]", "regenerate the README breakpoint reference", runBreakpoints},
+	{"dlvinit", "[-check] [module...]", "write each module's lab.dlv Delve init script", runDlvInit},
+	{"launch", "[-check]", "regenerate the module entries of .vscode/launch.json", runLaunch},
+	{"validate", "[module...]", "validate each module's lab.json manifest", runValidate},
+	{"check", "[module...]", "verify lab.json observations under Delve", runCheck},
+	{"escape", "[-readme] [module...]", "explain escape analysis at 🤔 markers and check lab.json claims", runEscape},
+	{"walk", "[flags] <module> [-- args]", "replay the breakpoints through dlv dap as JSON", runWalk},
+	{"quiz", "[-n] <module>", "answer the module's questions and record the score", runQuiz},
+	{"progress", "[-csv] [-learner name]", "report completed modules, failed checks and time", runProgress},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: labctl <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %-30s %s\n", c.name, c.args, c.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "A module is a number (2, 02), a directory name or its slug.")
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("labctl: ")
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name, args := os.Args[1], os.Args[2:]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage()
+		return
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		log.Printf("unknown command %q", name)
+		usage()
+		os.Exit(2)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	root, err := lab.FindRoot(wd)
+	if err != nil {
+		log.Fatal(err)
+	}
+	mods, err := lab.Modules(root)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = cmd.run(&env{root: root, modules: mods}, args)
+	var exit *exec.ExitError
+	switch {
+	case errors.As(err, &exit):
+		// The child already reported its failure on our stderr.
+		os.Exit(exit.ExitCode())
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case err != nil:
+		log.Fatal(err)
+	}
+}
+
+// moduleArg resolves the leading <module> argument and returns the
+// arguments that follow it, minus an optional "--" separator.
+func (e *env) moduleArg(args []string) (lab.Module, []string, error) {
+	if len(args) == 0 {
+		return lab.Module{}, nil, errors.New("missing module argument")
+	}
+	m, err := lab.Lookup(e.modules, args[0])
+	if err != nil {
+		return lab.Module{}, nil, err
+	}
+	rest := args[1:]
+	if len(rest) > 0 && rest[0] == "--" {
+		rest = rest[1:]
+	}
+	return m, rest, nil
+}
+
+// lookupAll resolves module arguments; no arguments means every module.
+func (e *env) lookupAll(queries []string) ([]lab.Module, error) {
+	if len(queries) == 0 {
+		return e.modules, nil
+	}
+	var mods []lab.Module
+	for _, q := range queries {
+		m, err := lab.Lookup(e.modules, q)
+		if err != nil {
+			return nil, err
+		}
+		mods = append(mods, m)
+	}
+	return mods, nil
+}
+
+// execIn runs name with args inside the module directory, wired to the
+// terminal, after echoing the equivalent shell command.
+func execIn(m lab.Module, name string, args ...string) error {
+	shown := []string{name}
+	for _, a := range args {
+		if strings.ContainsAny(a, " '") {
+			a = strconv.Quote(a)
+		}
+		shown = append(shown, a)
+	}
+	fmt.Fprintf(os.Stderr, "labctl: (cd %s && %s)\n", m.Name, strings.Join(shown, " "))
+	cmd := exec.Command(name, args...)
+	cmd.Dir = m.Dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// Ctrl-C is meant for the child, such as Delve pausing the program;
+	// labctl waits for it instead of dying first. Catching the signal
+	// rather than ignoring it keeps the child's default handling.
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	defer signal.Stop(sig)
+	return cmd.Run()
+}
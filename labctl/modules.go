@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/tabwriter"
+
+	"debugger-lab/labctl/internal/lab"
+)
+
+func runList(e *env, args []string) error {
+	if len(args) > 0 {
+		return errors.New("list takes no arguments")
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NN\tDIRECTORY\tTITLE\tKIND")
+	for _, m := range e.modules {
+		kind := "program"
+		if !m.IsMain() {
+			kind = "tests (package " + m.Package + ")"
+		}
+		fmt.Fprintf(w, "%02d\t%s\t%s\t%s\n", m.Number, m.Name, m.Title, kind)
+	}
+	return w.Flush()
+}
+
+func runRun(e *env, args []string) error {
+	m, rest, err := e.moduleArg(args)
+	if err != nil {
+		return err
+	}
+	if !m.IsMain() {
+		return fmt.Errorf("%s has no main package; use labctl test %02d", m.Name, m.Number)
+	}
+	defer trackSession(m, "run")()
+	return execIn(m, "go", append([]string{"run", "."}, rest...)...)
+}
+
+func runTest(e *env, args []string) error {
+	m, rest, err := e.moduleArg(args)
+	if err != nil {
+		return err
+	}
+	return execIn(m, "go", append([]string{"test", "./..."}, rest...)...)
+}
+
+// runDebug starts an interactive Delve session built the same way as the
+// "Debug Module NN" launch configurations, with the breakpoints from the
+// module's lab.dlv preloaded. Program modules use dlv debug; test-only
+// modules use dlv test, where the trailing arguments are test flags such
+// as -test.run. With -headless it starts a server on the module's debug
+// port instead, for the "Attach to Module NN" configuration.
+func runDebug(e *env, args []string) error {
+	fs := flag.NewFlagSet("debug", flag.ContinueOnError)
+	headless := fs.Bool("headless", false, "listen on the module's port (2300+NN) for an editor to attach")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	m, rest, err := e.moduleArg(fs.Args())
+	if err != nil {
+		return err
+	}
+	dlv, err := exec.LookPath("dlv")
+	if err != nil {
+		return errors.New("dlv not found in PATH: go install github.com/go-delve/delve/cmd/dlv@latest")
+	}
+	mode := "debug"
+	if !m.IsMain() {
+		mode = "test"
+	}
+	dlvArgs := []string{mode, "--build-flags=-gcflags='" + lab.DebugGCFlags + "'"}
+	if *headless {
+		dlvArgs = append(dlvArgs, "--headless", "--accept-multiclient", "--api-version=2",
+			fmt.Sprintf("--listen=127.0.0.1:%d", m.DebugPort()))
+	} else if _, err := os.Stat(filepath.Join(m.Dir, dlvInitFile)); err == nil {
+		dlvArgs = append(dlvArgs, "--init", dlvInitFile)
+	}
+	if len(rest) > 0 {
+		dlvArgs = append(append(dlvArgs, "--"), rest...)
+	}
+	defer trackSession(m, "debug")()
+	return execIn(m, dlv, dlvArgs...)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/progress"
+)
+
+// runProgress reports what the learner has done in every module, from
+// the progress store that quiz, check, run and debug keep up to date.
+// With -csv it writes the same as CSV, for whoever tracks a team.
+func runProgress(e *env, args []string) error {
+	fs := flag.NewFlagSet("progress", flag.ContinueOnError)
+	asCSV := fs.Bool("csv", false, "write one CSV row per module instead of the report")
+	learner := fs.String("learner", "", "record `name` as the learner shown in reports and exports")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New("progress takes no module arguments")
+	}
+	path, err := progress.Path()
+	if err != nil {
+		return err
+	}
+	s, err := progress.Load(path)
+	if err != nil {
+		return err
+	}
+	if *learner != "" {
+		s.Learner = *learner
+		if err := s.Save(path); err != nil {
+			return err
+		}
+	}
+	name := s.Learner
+	if name == "" {
+		name = loginName()
+	}
+
+	names := make([]string, len(e.modules))
+	for i, m := range e.modules {
+		names[i] = m.Name
+	}
+	sums := s.Summarize(names)
+	if *asCSV {
+		return writeProgressCSV(name, e.modules, sums)
+	}
+
+	fmt.Printf("Progress of %s (%s)\n\n", name, path)
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NN\tMODULE\tSTATUS\tQUIZ\tCHECKS\tTIME")
+	completed, total := 0, time.Duration(0)
+	for i, sum := range sums {
+		m := e.modules[i]
+		quiz, checks, spent := "-", "-", "-"
+		if q := sum.BestQuiz; q != nil {
+			quiz = fmt.Sprintf("%d/%d", q.Score, q.Total)
+			if sum.Attempts > 1 {
+				quiz += fmt.Sprintf(" (%d tries)", sum.Attempts)
+			}
+		}
+		if c := sum.LastCheck; c != nil {
+			checks = fmt.Sprintf("%d/%d", c.Total-len(c.Failed), c.Total)
+		}
+		if sum.Time > 0 {
+			spent = formatDuration(sum.Time)
+		}
+		fmt.Fprintf(w, "%02d\t%s\t%s\t%s\t%s\t%s\n", m.Number, m.Slug(), sum.Status(), quiz, checks, spent)
+		if sum.Completed() {
+			completed++
+		}
+		total += sum.Time
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, sum := range sums {
+		if c := sum.LastCheck; c != nil && !c.Passed() {
+			fmt.Printf("\nFailed checks in %s (%s):\n", sum.Module, c.Time.Local().Format(time.DateTime))
+			for _, f := range c.Failed {
+				fmt.Printf("  %s\n", f)
+			}
+		}
+	}
+	fmt.Printf("\nCompleted %d of %d modules; %s spent in labctl run, debug and quiz.\n",
+		completed, len(sums), formatDuration(total))
+	return nil
+}
+
+func writeProgressCSV(learner string, mods []lab.Module, sums []progress.Summary) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{
+		"learner", "module", "title", "status",
+		"quiz_score", "quiz_total", "quiz_attempts",
+		"checks_passed", "checks_total", "failed_checks", "minutes",
+	})
+	for i, sum := range sums {
+		var score, qtotal, passed, ctotal, failed string
+		if q := sum.BestQuiz; q != nil {
+			score, qtotal = strconv.Itoa(q.Score), strconv.Itoa(q.Total)
+		}
+		if c := sum.LastCheck; c != nil {
+			passed, ctotal = strconv.Itoa(c.Total-len(c.Failed)), strconv.Itoa(c.Total)
+			failed = strings.Join(c.Failed, "; ")
+		}
+		w.Write([]string{
+			learner, sum.Module, mods[i].Title, sum.Status(),
+			score, qtotal, strconv.Itoa(sum.Attempts),
+			passed, ctotal, failed, strconv.Itoa(int(sum.Time.Round(time.Minute) / time.Minute)),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// formatDuration prints d to the minute, such as "1h05m" or "12m".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return fmt.Sprintf("%dh%02dm", d/time.Hour, d%time.Hour/time.Minute)
+}
+
+func loginName() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// recordProgress applies f to the progress store. Progress is a side
+// effect of the lab tooling, so failing to record it is reported but
+// does not fail the command.
+func recordProgress(f func(s *progress.Store)) {
+	if err := progress.Update(f); err != nil {
+		log.Printf("recording progress: %v", err)
+	}
+}
+
+// trackSession records the time until the returned func is called as
+// spent on m in command.
+func trackSession(m lab.Module, command string) func() {
+	start := time.Now()
+	return func() {
+		end := time.Now()
+		recordProgress(func(s *progress.Store) {
+			s.Sessions = append(s.Sessions, progress.Session{Module: m.Name, Command: command, Start: start, End: end})
+		})
+	}
+}
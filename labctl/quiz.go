@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"debugger-lab/labctl/internal/golden"
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/manifest"
+	"debugger-lab/labctl/internal/progress"
+)
+
+// runQuiz asks a module's "Questions to Answer" from its lab.json in the
+// terminal. Output questions are checked against a real run of the
+// program, made after the student has committed to an answer.
+func runQuiz(e *env, args []string) error {
+	fs := flag.NewFlagSet("quiz", flag.ContinueOnError)
+	dryRun := fs.Bool("n", false, "do not record the score or time spent")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	m, rest, err := e.moduleArg(fs.Args())
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("quiz takes a single module argument")
+	}
+	man, err := manifest.Load(m)
+	if err != nil {
+		return err
+	}
+	if len(man.Questions) == 0 {
+		return fmt.Errorf("%s has no questions", m.Name)
+	}
+	if !*dryRun {
+		defer trackSession(m, "quiz")()
+	}
+
+	fmt.Printf("Module %02d: %s — %d questions\n", m.Number, man.Title, len(man.Questions))
+	in := bufio.NewReader(os.Stdin)
+	var output []byte // the program's masked output, once run
+	result := progress.Quiz{Module: m.Name, Total: len(man.Questions)}
+	for i, q := range man.Questions {
+		fmt.Printf("\n%d/%d. %s\n", i+1, len(man.Questions), q.Prompt)
+		for j, c := range q.Choices {
+			if numbered(q) {
+				fmt.Printf("   %d) %s\n", j+1, c)
+			} else {
+				fmt.Printf("   - %s\n", c)
+			}
+		}
+		answer, err := ask(in, q)
+		if err != nil {
+			return err
+		}
+
+		var right bool
+		var want string
+		if q.Output != "" {
+			if output == nil {
+				fmt.Printf("   (running %s to check)\n", m.Name)
+				if output, err = programOutput(m, man); err != nil {
+					return err
+				}
+			}
+			if want, err = q.Printed(output); err != nil {
+				return err
+			}
+			right = manifest.Normalize(answer) == manifest.Normalize(want)
+		} else {
+			right = q.Accepts(answer)
+			want = q.Answers[0]
+		}
+
+		if right {
+			result.Score++
+			fmt.Println("   ✔ correct")
+		} else {
+			result.Missed = append(result.Missed, q.ID)
+			fmt.Printf("   ✘ answer: %s\n", want)
+		}
+		if q.Explain != "" {
+			fmt.Printf("   %s\n", q.Explain)
+		}
+	}
+
+	fmt.Printf("\nScore: %d/%d\n", result.Score, result.Total)
+	if *dryRun {
+		return nil
+	}
+	result.Time = time.Now()
+	recordProgress(func(s *progress.Store) {
+		s.Quizzes = append(s.Quizzes, result)
+	})
+	return nil
+}
+
+// ask reads an answer to q, skipping empty lines.
+func ask(in *bufio.Reader, q manifest.Question) (string, error) {
+	for {
+		fmt.Print("> ")
+		line, err := in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				err = errors.New("quiz ended before the last question; score not recorded")
+			}
+			return "", err
+		}
+		if answer := choose(q, line); answer != "" {
+			return answer, nil
+		}
+	}
+}
+
+// choose turns a line typed at the prompt into an answer to q. When
+// q's choices are numbered, a choice's number stands for its text.
+func choose(q manifest.Question, line string) string {
+	answer := strings.TrimSpace(line)
+	if n, err := strconv.Atoi(answer); err == nil && numbered(q) && n >= 1 && n <= len(q.Choices) {
+		return q.Choices[n-1]
+	}
+	return answer
+}
+
+// numbered reports whether q's choices can be picked by number. Not
+// when a choice starts with a digit itself: typing 2 must not pick the
+// second of "1" and "3", so those are answered by their text.
+func numbered(q manifest.Question) bool {
+	for _, c := range q.Choices {
+		if c != "" && '0' <= c[0] && c[0] <= '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// programOutput runs m as the golden test does and returns its output
+// with the module's mask applied.
+func programOutput(m lab.Module, man *manifest.Manifest) ([]byte, error) {
+	mask, err := golden.ParseMask(man.Mask)
+	if err != nil {
+		return nil, err
+	}
+	out, err := golden.Run(m, man.Args, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return mask.Apply(out), nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"debugger-lab/labctl/internal/manifest"
+)
+
+func TestChoose(t *testing.T) {
+	words := manifest.Question{ID: "words", Choices: []string{"yes", "no"}, Answers: []string{"no"}}
+	numbers := manifest.Question{ID: "numbers", Choices: []string{"1", "3"}, Answers: []string{"1"}}
+	sequences := manifest.Question{ID: "sequences", Choices: []string{"0 1 2", "3 3 3"}, Answers: []string{"3 3 3"}}
+	free := manifest.Question{ID: "free", Answers: []string{"42"}}
+	tests := []struct {
+		q     manifest.Question
+		typed string
+		want  string
+		right bool
+	}{
+		{words, "2\n", "no", true},
+		{words, " No \n", "No", true},
+		{words, "1", "yes", false},
+		{words, "3", "3", false},
+		{numbers, "1", "1", true},
+		{numbers, "2", "2", false}, // not the second choice, "3"
+		{numbers, "3", "3", false},
+		{sequences, "2", "2", false},
+		{sequences, "3 3 3", "3 3 3", true},
+		{free, "42", "42", true},
+		{free, "1", "1", false},
+	}
+	for _, tt := range tests {
+		got := choose(tt.q, tt.typed)
+		if got != tt.want {
+			t.Errorf("%s: choose(%q) = %q, want %q", tt.q.ID, tt.typed, got, tt.want)
+		}
+		if right := tt.q.Accepts(got); right != tt.right {
+			t.Errorf("%s: %q accepted = %v, want %v", tt.q.ID, tt.typed, right, tt.right)
+		}
+	}
+}
+
+func TestAskSkipsEmptyLines(t *testing.T) {
+	q := manifest.Question{ID: "words", Choices: []string{"yes", "no"}}
+	in := bufio.NewReader(strings.NewReader("\n  \n2\n"))
+	if got, err := ask(in, q); err != nil || got != "no" {
+		t.Errorf("ask = %q, %v, want \"no\"", got, err)
+	}
+	if _, err := ask(in, q); err == nil {
+		t.Error("ask at the end of the input succeeded")
+	}
+}
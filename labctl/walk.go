@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"debugger-lab/labctl/internal/dap"
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/manifest"
+	"debugger-lab/labctl/internal/markers"
+	"debugger-lab/labctl/internal/observe"
+)
+
+// walkthrough is what `labctl walk` writes: every stop of a headless
+// session through a module's 🔍 breakpoints, with the Variables panel as
+// VS Code would show it there.
+type walkthrough struct {
+	Module    string     `json:"module"`
+	GoVersion string     `json:"goVersion"`
+	Stops     []walkStop `json:"stops"`
+}
+
+type walkStop struct {
+	Reason string    `json:"reason"`         // "breakpoint", "step", "panic", ...
+	Step   string    `json:"step,omitempty"` // the step command that led here
+	Frame  dap.Frame `json:"frame"`
+}
+
+// maxStops bounds a walkthrough in case a breakpoint sits in a loop that
+// never ends.
+const maxStops = 500
+
+// runWalk replays a module's breakpoints through dlv dap, the adapter VS
+// Code uses, and prints the walkthrough as JSON. Running it under two Go
+// toolchains and diffing the output shows what changed in the panel.
+func runWalk(e *env, args []string) error {
+	fs := flag.NewFlagSet("walk", flag.ContinueOnError)
+	out := fs.String("o", "", "write the walkthrough to `file` instead of stdout")
+	steps := fs.String("steps", "", "comma-separated `commands` (next, in, out) to run after each breakpoint")
+	hits := fs.Int("hits", 3, "stop at most `n` times at each breakpoint")
+	depth := fs.Int("depth", 2, "expand variables `n` levels deep")
+	raw := fs.Bool("raw", false, "keep pointer values instead of masking them as 0x…")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	m, rest, err := e.moduleArg(fs.Args())
+	if err != nil {
+		return err
+	}
+	var stepCmds []string
+	if *steps != "" {
+		stepCmds = strings.Split(*steps, ",")
+		for _, s := range stepCmds {
+			if s != "next" && s != "in" && s != "out" {
+				return fmt.Errorf("unknown step %q: want next, in or out", s)
+			}
+		}
+	}
+	if err := observe.Available(); err != nil {
+		return err
+	}
+
+	found, err := markers.ParseDir(m.Dir)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		if man, err := manifest.Load(m); err == nil {
+			rest = man.Args
+		}
+	}
+	w := walkthrough{Module: m.Name, GoVersion: goVersion(m)}
+	var log bytes.Buffer
+	if err := walk(m, found, rest, stepCmds, *hits, *depth, &w, &log); err != nil {
+		os.Stderr.Write(log.Bytes())
+		return err
+	}
+	if !*raw {
+		for i := range w.Stops {
+			w.Stops[i].Frame.MaskAddresses()
+		}
+	}
+
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*out, data, 0o644)
+}
+
+func walk(m lab.Module, found []markers.Marker, args, steps []string, maxHits, depth int, w *walkthrough, log *bytes.Buffer) error {
+	server, err := dap.Start(m.Dir, log)
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+	client, err := dap.Dial(server.Addr)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(true)
+	client.Output = os.Stderr
+
+	if err := client.Initialize(); err != nil {
+		return err
+	}
+	mode := "debug"
+	if !m.IsMain() {
+		mode = "test"
+	}
+	err = client.Launch(dap.LaunchArgs{
+		Mode:                mode,
+		Program:             m.Dir,
+		Args:                args,
+		Cwd:                 m.Dir,
+		BuildFlags:          `-gcflags="` + lab.DebugGCFlags + `"`,
+		ShowGlobalVariables: true,
+	})
+	if err != nil {
+		return err
+	}
+	return replay(client, m, found, steps, maxHits, depth, w)
+}
+
+// session is the part of a dap.Client that replay drives.
+type session interface {
+	SetBreakpoints(path string, bps []dap.SourceBreakpoint) ([]dap.Breakpoint, error)
+	ConfigurationDone() error
+	Wait() (dap.Stop, error)
+	Continue(threadID int) (dap.Stop, error)
+	Next(threadID int) (dap.Stop, error)
+	StepIn(threadID int) (dap.Stop, error)
+	StepOut(threadID int) (dap.Stop, error)
+	Snapshot(threadID, depth int, dir string) (dap.Frame, error)
+}
+
+// replay sets a breakpoint at every 🔍 marker of a launched session,
+// records each stop and the steps after it in w, and continues until
+// the program ends. A breakpoint is cleared once it has stopped the
+// program maxHits times.
+func replay(client session, m lab.Module, found []markers.Marker, steps []string, maxHits, depth int, w *walkthrough) error {
+	// One breakpoint per statement, grouped by file as setBreakpoints
+	// wants them.
+	byFile := map[string][]dap.SourceBreakpoint{}
+	seen := map[string]bool{}
+	for _, mk := range found {
+		if mk.Kind != markers.Breakpoint || seen[mk.Pos()] {
+			continue
+		}
+		seen[mk.Pos()] = true
+		byFile[mk.File] = append(byFile[mk.File], dap.SourceBreakpoint{Line: mk.StmtLine, Condition: mk.Condition})
+	}
+	type placed struct {
+		file string
+		line int
+	}
+	ids := map[int]placed{}
+	set := func(file string) error {
+		bps, err := client.SetBreakpoints(filepath.Join(m.Dir, filepath.FromSlash(file)), byFile[file])
+		if err != nil {
+			return fmt.Errorf("breakpoints in %s: %w", file, err)
+		}
+		for i, bp := range bps {
+			ids[bp.ID] = placed{file, byFile[file][i].Line}
+		}
+		return nil
+	}
+	for file := range byFile {
+		if err := set(file); err != nil {
+			return err
+		}
+	}
+	if err := client.ConfigurationDone(); err != nil {
+		return err
+	}
+
+	hits := map[placed]int{}
+	stop, err := client.Wait()
+	for err == nil && !stop.Terminated && len(w.Stops) < maxStops {
+		if err = record(client, m, stop, "", depth, w); err != nil {
+			break
+		}
+		// Steps replace stop, so keep the breakpoints this stop hit.
+		hit := stop.HitBreakpointIDs
+		for _, s := range steps {
+			switch s {
+			case "next":
+				stop, err = client.Next(stop.ThreadID)
+			case "in":
+				stop, err = client.StepIn(stop.ThreadID)
+			case "out":
+				stop, err = client.StepOut(stop.ThreadID)
+			}
+			if err != nil || stop.Terminated {
+				break
+			}
+			if err = record(client, m, stop, s, depth, w); err != nil {
+				break
+			}
+		}
+		if err != nil || stop.Terminated {
+			break
+		}
+
+		// Retire breakpoints that have been hit often enough, so that
+		// loops such as module 11's 10000 increments stay short.
+		for _, id := range hit {
+			p, ok := ids[id]
+			if !ok {
+				continue
+			}
+			if hits[p]++; hits[p] < maxHits {
+				continue
+			}
+			bps := byFile[p.file][:0:0]
+			for _, bp := range byFile[p.file] {
+				if bp.Line != p.line {
+					bps = append(bps, bp)
+				}
+			}
+			byFile[p.file] = bps
+			if err = set(p.file); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+		stop, err = client.Continue(stop.ThreadID)
+	}
+	return err
+}
+
+func record(c session, m lab.Module, stop dap.Stop, step string, depth int, w *walkthrough) error {
+	frame, err := c.Snapshot(stop.ThreadID, depth, m.Dir)
+	if err != nil {
+		return err
+	}
+	w.Stops = append(w.Stops, walkStop{Reason: stop.Reason, Step: step, Frame: frame})
+	return nil
+}
+
+// goVersion reports the toolchain that builds m, which GOTOOLCHAIN may
+// select.
+func goVersion(m lab.Module) string {
+	cmd := exec.Command("go", "env", "GOVERSION")
+	cmd.Dir = m.Dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"debugger-lab/labctl/internal/dap"
+	"debugger-lab/labctl/internal/lab"
+	"debugger-lab/labctl/internal/markers"
+)
+
+// loopSession is a session whose program reaches a single breakpoint
+// laps times, as long as the breakpoint is set.
+type loopSession struct {
+	laps int
+	set  bool
+}
+
+func (s *loopSession) SetBreakpoints(path string, bps []dap.SourceBreakpoint) ([]dap.Breakpoint, error) {
+	s.set = len(bps) > 0
+	placed := make([]dap.Breakpoint, len(bps))
+	for i, bp := range bps {
+		placed[i] = dap.Breakpoint{ID: i + 1, Verified: true, Line: bp.Line}
+	}
+	return placed, nil
+}
+
+func (s *loopSession) ConfigurationDone() error { return nil }
+func (s *loopSession) Wait() (dap.Stop, error)  { return s.Continue(1) }
+
+func (s *loopSession) Continue(threadID int) (dap.Stop, error) {
+	if !s.set || s.laps == 0 {
+		return dap.Stop{Terminated: true}, nil
+	}
+	s.laps--
+	return dap.Stop{Reason: "breakpoint", ThreadID: threadID, HitBreakpointIDs: []int{1}}, nil
+}
+
+func (s *loopSession) Next(threadID int) (dap.Stop, error) {
+	return dap.Stop{Reason: "step", ThreadID: threadID}, nil
+}
+func (s *loopSession) StepIn(threadID int) (dap.Stop, error)  { return s.Next(threadID) }
+func (s *loopSession) StepOut(threadID int) (dap.Stop, error) { return s.Next(threadID) }
+
+func (s *loopSession) Snapshot(threadID, depth int, dir string) (dap.Frame, error) {
+	return dap.Frame{}, nil
+}
+
+// TestReplayRetires checks that a breakpoint in a loop is cleared after
+// maxHits stops, also when steps after each stop land where no
+// breakpoint is.
+func TestReplayRetires(t *testing.T) {
+	found := []markers.Marker{{Kind: markers.Breakpoint, File: "main.go", Line: 9, StmtLine: 10, Func: "main"}}
+	for _, steps := range [][]string{nil, {"next"}, {"next", "in"}} {
+		var w walkthrough
+		s := &loopSession{laps: 10}
+		if err := replay(s, lab.Module{Dir: t.TempDir()}, found, steps, 2, 1, &w); err != nil {
+			t.Fatal(err)
+		}
+		breakpoints := 0
+		for _, stop := range w.Stops {
+			if stop.Reason == "breakpoint" {
+				breakpoints++
+			}
+		}
+		if want := 2 * (1 + len(steps)); breakpoints != 2 || len(w.Stops) != want {
+			t.Errorf("steps %q: %d stops, %d at the breakpoint; want %d, 2", steps, len(w.Stops), breakpoints, want)
+		}
+	}
+}
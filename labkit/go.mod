@@ -0,0 +1,3 @@
+module debugger-lab/labkit
+
+go 1.25
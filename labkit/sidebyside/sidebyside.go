@@ -0,0 +1,62 @@
+// Package sidebyside prints what two variants of the same code printed
+// next to each other, as diff -y does. Labs use it to run a loop
+// compiled with go 1.21 semantics (legacy/) against the same loop under
+// the module's own go version (current/):
+//
+//	if err := sidebyside.Print(os.Stdout, "go 1.21 (legacy/)", before, "go 1.25 (current/)", after); err != nil {
+//		fmt.Fprintln(os.Stderr, err)
+//		os.Exit(1)
+//	}
+package sidebyside
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// column is the width of the left column; it is fixed so that the
+// columns line up the same whatever the width of the addresses printed.
+const column = 36
+
+// address matches a pointer as fmt prints it.
+var address = regexp.MustCompile(`0x[0-9a-f]+`)
+
+// Print prints left and right in two columns under their titles,
+// marking lines that differ with |. It returns an error if no line
+// differs other than in the addresses it prints: the two variants then
+// behave the same, and the lab's "buggy" and "fixed" labels are wrong.
+func Print(w io.Writer, leftTitle, left, rightTitle, right string) error {
+	l := strings.Split(strings.TrimSuffix(left, "\n"), "\n")
+	r := strings.Split(strings.TrimSuffix(right, "\n"), "\n")
+	fmt.Fprintln(w, row(leftTitle, "   ", rightTitle))
+	same := true
+	for i := range max(len(l), len(r)) {
+		var a, b string
+		if i < len(l) {
+			a = l[i]
+		}
+		if i < len(r) {
+			b = r[i]
+		}
+		sep := "   "
+		if a != b {
+			sep = " | "
+		}
+		if address.ReplaceAllString(a, "0x") != address.ReplaceAllString(b, "0x") {
+			same = false
+		}
+		fmt.Fprintln(w, row(a, sep, b))
+	}
+	if same {
+		return fmt.Errorf("%s and %s printed the same", leftTitle, rightTitle)
+	}
+	return nil
+}
+
+func row(a, sep, b string) string {
+	pad := strings.Repeat(" ", max(0, column-utf8.RuneCountInString(a)))
+	return strings.TrimRight(a+pad+sep+b, " ")
+}
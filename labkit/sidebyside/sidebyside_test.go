@@ -0,0 +1,36 @@
+package sidebyside
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrint(t *testing.T) {
+	var out strings.Builder
+	err := Print(&out, "legacy", "i = 3\ni = 3\n", "current", "i = 0\ni = 3\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "legacy                                 current\n" +
+		"i = 3                                | i = 0\n" +
+		"i = 3                                  i = 3\n"
+	if out.String() != want {
+		t.Errorf("Print wrote\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestPrintSame(t *testing.T) {
+	tests := []struct {
+		name        string
+		left, right string
+	}{
+		{"identical", "i = 0\ni = 1\n", "i = 0\ni = 1\n"},
+		{"addresses only", "&i = 0xc000012080\n", "&i = 0xc0000120a8\n"},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		if err := Print(&out, "legacy", tt.left, "current", tt.right); err == nil {
+			t.Errorf("%s: Print reported no error for outputs that do not differ", tt.name)
+		}
+	}
+}
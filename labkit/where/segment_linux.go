@@ -0,0 +1,138 @@
+package where
+
+import (
+	"bufio"
+	"debug/elf"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type span struct {
+	lo, hi uintptr
+	class  Class
+}
+
+// sections lists the binary's data and bss sections, where they are in
+// this process, once.
+var sections = sync.OnceValue(func() []span {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil
+	}
+	f, err := elf.Open(exe)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	// A position-independent binary is loaded wherever the kernel
+	// chose: its sections are shifted by the start of its first mapping.
+	var bias uintptr
+	if f.Type == elf.ET_DYN {
+		base, ok := loadBase(exe)
+		if !ok {
+			return nil
+		}
+		for _, p := range f.Progs {
+			if p.Type == elf.PT_LOAD {
+				bias = base - uintptr(p.Vaddr&^(p.Align-1))
+				break
+			}
+		}
+	}
+
+	var spans []span
+	for _, s := range f.Sections {
+		// The Go linker keeps variables that hold no pointers apart, so
+		// the collector need not scan them.
+		var c Class
+		switch s.Name {
+		case ".data", ".noptrdata":
+			c = Data
+		case ".bss", ".noptrbss":
+			c = BSS
+		default:
+			continue
+		}
+		lo := uintptr(s.Addr) + bias
+		spans = append(spans, span{lo, lo + uintptr(s.Size), c})
+	}
+	return spans
+})
+
+// segment classifies an address that is not on the current stack, from
+// the binary's section headers and the process's memory map.
+func segment(a uintptr) Class {
+	for _, s := range sections() {
+		if s.lo <= a && a < s.hi {
+			return s.class
+		}
+	}
+	if anonymous(a) {
+		// The Go heap is made of anonymous mappings; the binary and
+		// shared libraries are mapped from files.
+		return Heap
+	}
+	return Unknown
+}
+
+// A mapping is a line of /proc/self/maps:
+//
+//	c000000000-c004000000 rw-p 00000000 00:00 0
+//	00400000-0048b000 r-xp 00000000 fd:01 1314 /tmp/go-build/b001/exe/lab
+type mapping struct {
+	lo, hi uintptr
+	offset uint64
+	path   string // empty for anonymous memory
+}
+
+func mappings() []mapping {
+	f, err := os.Open("/proc/self/maps")
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var maps []mapping
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		from, to, _ := strings.Cut(fields[0], "-")
+		lo, err1 := strconv.ParseUint(from, 16, 64)
+		hi, err2 := strconv.ParseUint(to, 16, 64)
+		off, err3 := strconv.ParseUint(fields[2], 16, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		m := mapping{lo: uintptr(lo), hi: uintptr(hi), offset: off}
+		if len(fields) > 5 {
+			m.path = fields[5]
+		}
+		maps = append(maps, m)
+	}
+	return maps
+}
+
+// loadBase returns where the start of the file exe is mapped.
+func loadBase(exe string) (uintptr, bool) {
+	for _, m := range mappings() {
+		if m.path == exe && m.offset == 0 {
+			return m.lo, true
+		}
+	}
+	return 0, false
+}
+
+// anonymous reports whether a is in memory that no file backs.
+func anonymous(a uintptr) bool {
+	for _, m := range mappings() {
+		if m.lo <= a && a < m.hi {
+			return m.path == ""
+		}
+	}
+	return false
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package where
+
+// segment needs an ELF binary and /proc/self/maps.
+func segment(a uintptr) Class {
+	return Unknown
+}
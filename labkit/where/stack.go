@@ -0,0 +1,41 @@
+//go:build amd64 || arm64
+
+package where
+
+import "unsafe"
+
+// gStack is the start of the runtime's g, the goroutine descriptor:
+//
+//	type g struct {
+//		stack stack // [lo, hi)
+//		...
+//
+// The runtime has kept stack as the first field since Go 1.4, but g is
+// private to it, and nothing exported says where the stack is:
+// runtime/debug reports stack sizes only, and the symbol table locates
+// the data and bss segments but not goroutine stacks. stackBounds checks
+// that the bounds hold its own frame before trusting them.
+type gStack struct {
+	lo, hi uintptr
+}
+
+// getg returns the running goroutine's g, implemented in assembly: the
+// runtime keeps it in thread-local storage on amd64 and in R28 on arm64.
+func getg() *gStack
+
+// stackBounds returns the bounds of the running goroutine's stack, or
+// 0, 0 if g is not laid out as this package expects. It must not grow
+// the stack itself.
+//
+//go:nosplit
+func stackBounds() (lo, hi uintptr) {
+	g := getg()
+	if g == nil {
+		return 0, 0
+	}
+	var probe byte
+	if sp := uintptr(unsafe.Pointer(&probe)); g.lo >= g.hi || sp < g.lo || sp >= g.hi {
+		return 0, 0
+	}
+	return g.lo, g.hi
+}
@@ -0,0 +1,9 @@
+//go:build !amd64 && !arm64
+
+package where
+
+// stackBounds knows no stack on architectures without getg; stack
+// addresses fall through to segment, which reports them as heap.
+func stackBounds() (lo, hi uintptr) {
+	return 0, 0
+}
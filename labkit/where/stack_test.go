@@ -0,0 +1,17 @@
+//go:build amd64 || arm64
+
+package where
+
+import "testing"
+
+// TestStackBounds fails when the runtime's g no longer starts with the
+// stack bounds: Of would then report every stack address as heap.
+func TestStackBounds(t *testing.T) {
+	lo, hi := stackBounds()
+	if lo == 0 && hi == 0 {
+		t.Fatal("stackBounds() = 0, 0: the layout of the runtime's g changed; update gStack")
+	}
+	if lo >= hi {
+		t.Errorf("stackBounds() = %#x, %#x, want lo < hi", lo, hi)
+	}
+}
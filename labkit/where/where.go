@@ -0,0 +1,48 @@
+// Package where tells which part of memory an address is in: the stack
+// of the running goroutine, the Go heap, or the data and bss segments of
+// the program's binary. Labs print it next to the address, printed as a
+// number:
+//
+//	fmt.Printf("&local = %#x %s\n", uintptr(unsafe.Pointer(&local)), where.Of(&local))
+//	// &local = 0xc000046708 [stack]
+//
+// Do not print &local itself with %p: that is enough to move local to
+// the heap, since fmt takes its arguments as interfaces and the compiler
+// cannot prove that fmt does not keep them. Of does not move what it is
+// given.
+package where
+
+import "unsafe"
+
+// Class is the part of memory an address belongs to.
+type Class int
+
+const (
+	Unknown Class = iota
+	Stack         // the stack of the goroutine calling Of
+	Heap          // allocated by the Go runtime; other goroutines' stacks too
+	Data          // package variables with an initial value
+	BSS           // package variables that start as zero
+)
+
+var names = [...]string{"unknown", "stack", "heap", "data", "bss"}
+
+// String returns the class in brackets, as in "[heap]".
+func (c Class) String() string {
+	return "[" + names[c] + "]"
+}
+
+// Of reports where *p is.
+func Of[T any](p *T) Class {
+	// The address is compared with the stack bounds before anything
+	// else is called: a call may grow the stack, which moves it, and a
+	// uintptr is not updated when the stack moves.
+	a := uintptr(unsafe.Pointer(p))
+	if lo, hi := stackBounds(); lo <= a && a < hi {
+		return Stack
+	}
+	if a == 0 {
+		return Unknown
+	}
+	return segment(a)
+}
@@ -0,0 +1,53 @@
+package where
+
+import (
+	"runtime"
+	"testing"
+)
+
+var (
+	initialized = []int{1, 2, 3}
+	zero        [4]int
+	sink        *int
+)
+
+func TestOf(t *testing.T) {
+	local := 1
+	sink = new(int)
+	tests := []struct {
+		name string
+		got  Class
+		want Class
+	}{
+		{"&local", Of(&local), Stack},
+		{"new(int)", Of(sink), Heap},
+		{"&initialized", Of(&initialized), Data},
+		{"&zero", Of(&zero), BSS},
+		{"nil", Of[int](nil), Unknown},
+	}
+	for _, tt := range tests {
+		if runtime.GOOS != "linux" && tt.want != Stack {
+			continue
+		}
+		if tt.got != tt.want {
+			t.Errorf("Of(%s) = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// TestOfAfterGrowth checks that a stack that moved is still recognized.
+func TestOfAfterGrowth(t *testing.T) {
+	local := 1
+	grow(1000)
+	if got := Of(&local); got != Stack {
+		t.Errorf("Of(&local) after the stack grew = %v, want [stack]", got)
+	}
+}
+
+func grow(n int) int {
+	var pad [64]byte
+	if n == 0 {
+		return int(pad[0])
+	}
+	return grow(n-1) + int(pad[n%64])
+}